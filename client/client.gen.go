@@ -0,0 +1,5325 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v1.13.4 DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+)
+
+const (
+	ApiKeyScopes        = "ApiKey.Scopes"
+	BearerScopes        = "Bearer.Scopes"
+	HmacSignatureScopes = "HmacSignature.Scopes"
+)
+
+// Defines values for CheckResultStatus.
+const (
+	CheckResultStatusError CheckResultStatus = "error"
+	CheckResultStatusOk    CheckResultStatus = "ok"
+)
+
+// Defines values for FigureType.
+const (
+	FigureTypeFigure FigureType = "figure"
+	FigureTypeTable  FigureType = "table"
+)
+
+// Defines values for GrobidOptionsTeiCoordinates.
+const (
+	GrobidOptionsTeiCoordinatesAffiliation GrobidOptionsTeiCoordinates = "affiliation"
+	GrobidOptionsTeiCoordinatesBiblStruct  GrobidOptionsTeiCoordinates = "biblStruct"
+	GrobidOptionsTeiCoordinatesFigure      GrobidOptionsTeiCoordinates = "figure"
+	GrobidOptionsTeiCoordinatesFormula     GrobidOptionsTeiCoordinates = "formula"
+	GrobidOptionsTeiCoordinatesHead        GrobidOptionsTeiCoordinates = "head"
+	GrobidOptionsTeiCoordinatesNote        GrobidOptionsTeiCoordinates = "note"
+	GrobidOptionsTeiCoordinatesP           GrobidOptionsTeiCoordinates = "p"
+	GrobidOptionsTeiCoordinatesPersName    GrobidOptionsTeiCoordinates = "persName"
+	GrobidOptionsTeiCoordinatesRef         GrobidOptionsTeiCoordinates = "ref"
+	GrobidOptionsTeiCoordinatesS           GrobidOptionsTeiCoordinates = "s"
+	GrobidOptionsTeiCoordinatesTitle       GrobidOptionsTeiCoordinates = "title"
+)
+
+// Defines values for JobStage.
+const (
+	JobStageCrossref   JobStage = "crossref"
+	JobStageDbWrite    JobStage = "db_write"
+	JobStageDone       JobStage = "done"
+	JobStageGrobid     JobStage = "grobid"
+	JobStageQueued     JobStage = "queued"
+	JobStageS3Download JobStage = "s3_download"
+)
+
+// Defines values for JobStatus.
+const (
+	JobStatusDuplicate  JobStatus = "duplicate"
+	JobStatusFailed     JobStatus = "failed"
+	JobStatusInProgress JobStatus = "in_progress"
+	JobStatusQueued     JobStatus = "queued"
+	JobStatusSucceeded  JobStatus = "succeeded"
+)
+
+// Defines values for LivenessStatus.
+const (
+	LivenessStatusError LivenessStatus = "error"
+	LivenessStatusOk    LivenessStatus = "ok"
+)
+
+// Defines values for PaperPublishedDatePrecision.
+const (
+	PaperPublishedDatePrecisionDay   PaperPublishedDatePrecision = "day"
+	PaperPublishedDatePrecisionMonth PaperPublishedDatePrecision = "month"
+	PaperPublishedDatePrecisionYear  PaperPublishedDatePrecision = "year"
+)
+
+// Defines values for PaperDetailPublishedDatePrecision.
+const (
+	PaperDetailPublishedDatePrecisionDay   PaperDetailPublishedDatePrecision = "day"
+	PaperDetailPublishedDatePrecisionMonth PaperDetailPublishedDatePrecision = "month"
+	PaperDetailPublishedDatePrecisionYear  PaperDetailPublishedDatePrecision = "year"
+)
+
+// Defines values for ParsedFigureType.
+const (
+	ParsedFigureTypeFigure ParsedFigureType = "figure"
+	ParsedFigureTypeTable  ParsedFigureType = "table"
+)
+
+// Defines values for PoolStatusState.
+const (
+	Drained  PoolStatusState = "drained"
+	Draining PoolStatusState = "draining"
+	Paused   PoolStatusState = "paused"
+	Running  PoolStatusState = "running"
+	Stopped  PoolStatusState = "stopped"
+)
+
+// Defines values for PublishedDatePrecision.
+const (
+	Day   PublishedDatePrecision = "day"
+	Month PublishedDatePrecision = "month"
+	Year  PublishedDatePrecision = "year"
+)
+
+// Defines values for ReadinessStatus.
+const (
+	ReadinessStatusError ReadinessStatus = "error"
+	ReadinessStatusOk    ReadinessStatus = "ok"
+)
+
+// Defines values for ReprocessRequestSource.
+const (
+	Pdf ReprocessRequestSource = "pdf"
+	Tei ReprocessRequestSource = "tei"
+)
+
+// Defines values for UploadManifestRejectedReason.
+const (
+	Duplicate  UploadManifestRejectedReason = "duplicate"
+	Encrypted  UploadManifestRejectedReason = "encrypted"
+	NotAPdf    UploadManifestRejectedReason = "not a pdf"
+	TooLarge   UploadManifestRejectedReason = "too large"
+	Unreadable UploadManifestRejectedReason = "unreadable"
+)
+
+// AbstractPart defines model for AbstractPart.
+type AbstractPart struct {
+	CreatedAt string `json:"created_at"`
+	Id        int64  `json:"id"`
+
+	// Label Omitted for unstructured abstracts
+	Label     *string `json:"label,omitempty"`
+	Order     int64   `json:"order"`
+	PaperId   int64   `json:"paper_id"`
+	Text      string  `json:"text"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+// Affiliation defines model for Affiliation.
+type Affiliation struct {
+	Country     string `json:"country"`
+	Department  string `json:"department"`
+	Institution string `json:"institution"`
+}
+
+// Box An area of a PDF page in PDF points from the top left corner
+type Box struct {
+	Height float32 `json:"height"`
+	Page   int     `json:"page"`
+	Width  float32 `json:"width"`
+	X      float32 `json:"x"`
+	Y      float32 `json:"y"`
+}
+
+// CheckResult defines model for CheckResult.
+type CheckResult struct {
+	CheckedAt   time.Time         `json:"checked_at"`
+	Error       *string           `json:"error,omitempty"`
+	LastError   *string           `json:"last_error,omitempty"`
+	LastErrorAt *time.Time        `json:"last_error_at,omitempty"`
+	LatencyMs   float32           `json:"latency_ms"`
+	Status      CheckResultStatus `json:"status"`
+}
+
+// CheckResultStatus defines model for CheckResult.Status.
+type CheckResultStatus string
+
+// Citation defines model for Citation.
+type Citation struct {
+	Id     int64   `json:"id"`
+	Marker *string `json:"marker,omitempty"`
+
+	// Offset Where the citation marker was in the section text, in characters
+	Offset      int64   `json:"offset"`
+	ReferenceId *int64  `json:"reference_id,omitempty"`
+	SectionId   int64   `json:"section_id"`
+	Target      *string `json:"target,omitempty"`
+}
+
+// ConsolidationLevel 0 for none, 1 for full consolidation, 2 to only add the DOI found, 3 to consolidate from the extracted DOI only
+type ConsolidationLevel = int
+
+// EnqueueRequest defines model for EnqueueRequest.
+type EnqueueRequest struct {
+	// GrobidOptions Grobid options over the defaults, fields that are not set keep them
+	GrobidOptions *GrobidOptions `json:"grobid_options,omitempty"`
+
+	// S3Location Key of the PDF in the upload bucket
+	S3Location string `json:"s3Location"`
+	ScreenId   int64  `json:"screen_id"`
+	UserId     int64  `json:"user_id"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	Error string `json:"error"`
+}
+
+// Figure defines model for Figure.
+type Figure struct {
+	Caption   *string `json:"caption,omitempty"`
+	Coords    []Box   `json:"coords"`
+	CreatedAt string  `json:"created_at"`
+	Id        int64   `json:"id"`
+	Label     *string `json:"label,omitempty"`
+	Order     int64   `json:"order"`
+	PaperId   int64   `json:"paper_id"`
+
+	// Rows The cells of a table, empty for figures
+	Rows      [][]string `json:"rows"`
+	TeiId     *string    `json:"tei_id,omitempty"`
+	Type      FigureType `json:"type"`
+	UpdatedAt string     `json:"updated_at"`
+}
+
+// FigureType defines model for Figure.Type.
+type FigureType string
+
+// GrobidOptions Grobid options over the defaults, fields that are not set keep them
+type GrobidOptions struct {
+	// ConsolidateCitations 0 for none, 1 for full consolidation, 2 to only add the DOI found, 3 to consolidate from the extracted DOI only
+	ConsolidateCitations *ConsolidationLevel `json:"consolidate_citations,omitempty"`
+
+	// ConsolidateHeader 0 for none, 1 for full consolidation, 2 to only add the DOI found, 3 to consolidate from the extracted DOI only
+	ConsolidateHeader *ConsolidationLevel `json:"consolidate_header,omitempty"`
+
+	// End Last page to process
+	End                    *int  `json:"end,omitempty"`
+	IncludeRawAffiliations *bool `json:"include_raw_affiliations,omitempty"`
+	IncludeRawCitations    *bool `json:"include_raw_citations,omitempty"`
+	SegmentSentences       *bool `json:"segment_sentences,omitempty"`
+
+	// Start First page to process
+	Start *int `json:"start,omitempty"`
+
+	// TeiCoordinates Replaces the default elements, an empty list turns coordinates off
+	TeiCoordinates *[]GrobidOptionsTeiCoordinates `json:"tei_coordinates,omitempty"`
+}
+
+// GrobidOptionsTeiCoordinates defines model for GrobidOptions.TeiCoordinates.
+type GrobidOptionsTeiCoordinates string
+
+// Job defines model for Job.
+type Job struct {
+	Error      *string    `json:"error,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Id         string     `json:"id"`
+	PaperId    *int64     `json:"paper_id,omitempty"`
+	QueuedAt   time.Time  `json:"queued_at"`
+	S3Location string     `json:"s3_location"`
+	ScreenId   int64      `json:"screen_id"`
+	Stage      JobStage   `json:"stage"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	Status     JobStatus  `json:"status"`
+	UserId     int64      `json:"user_id"`
+}
+
+// JobStage defines model for Job.Stage.
+type JobStage string
+
+// JobStatus defines model for Job.Status.
+type JobStatus string
+
+// Limits defines model for Limits.
+type Limits struct {
+	GracePeriodRequests              int     `json:"grace_period_requests"`
+	GracePeriodWorkers               int     `json:"grace_period_workers"`
+	MinimumGapBetweenRequestsSeconds float32 `json:"minimum_gap_between_requests_seconds"`
+}
+
+// LimitsUpdate defines model for LimitsUpdate.
+type LimitsUpdate struct {
+	GracePeriodRequests              *int     `json:"grace_period_requests,omitempty"`
+	GracePeriodWorkers               *int     `json:"grace_period_workers,omitempty"`
+	MinimumGapBetweenRequestsSeconds *float32 `json:"minimum_gap_between_requests_seconds,omitempty"`
+}
+
+// Liveness defines model for Liveness.
+type Liveness struct {
+	Error   *string        `json:"error,omitempty"`
+	Status  LivenessStatus `json:"status"`
+	Workers *[]WorkerInfo  `json:"workers,omitempty"`
+}
+
+// LivenessStatus defines model for Liveness.Status.
+type LivenessStatus string
+
+// PDFDTO defines model for PDFDTO.
+type PDFDTO struct {
+	// Abstract The text of the parts of the abstract joined without their labels, which are in abstract_parts
+	Abstract      string                `json:"abstract"`
+	AbstractParts *[]ParsedAbstractPart `json:"abstract_parts"`
+	Authors       *[]ParsedAuthor       `json:"authors"`
+	CustomKey     string                `json:"custom_key"`
+
+	// Date The publication date as printed in the PDF
+	Date    string          `json:"date"`
+	Doi     string          `json:"doi"`
+	Eissn   string          `json:"eissn"`
+	Figures *[]ParsedFigure `json:"figures"`
+
+	// Identifiers Normalised identifiers, empty when the PDF does not have them
+	Identifiers         ParsedIdentifiers `json:"identifiers"`
+	Issn                string            `json:"issn"`
+	Issue               string            `json:"issue"`
+	Journal             string            `json:"journal"`
+	JournalAbbreviation string            `json:"journal_abbreviation"`
+	Keywords            *[]string         `json:"keywords"`
+	Notes               string            `json:"notes"`
+
+	// Pages A page range such as "781-793", or the article number
+	Pages string `json:"pages"`
+
+	// PublishedDate The publication date read from the TEI, null when it could not be read or contradicts the Crossref year
+	PublishedDate *PublishedDate     `json:"published_date"`
+	Publisher     string             `json:"publisher"`
+	PubmedId      *interface{}       `json:"pubmed_id"`
+	References    *[]ParsedReference `json:"references"`
+	Sections      *[]ParsedSection   `json:"sections"`
+	Title         string             `json:"title"`
+	Volume        string             `json:"volume"`
+	Year          string             `json:"year"`
+}
+
+// Paper defines model for Paper.
+type Paper struct {
+	Abstract            string   `json:"abstract"`
+	ArxivId             *string  `json:"arxiv_id,omitempty"`
+	CreatedAt           string   `json:"created_at"`
+	CustomKey           *string  `json:"custom_key,omitempty"`
+	Doi                 *string  `json:"doi,omitempty"`
+	Eissn               *string  `json:"eissn,omitempty"`
+	Id                  int64    `json:"id"`
+	Isbn                *string  `json:"isbn,omitempty"`
+	Issn                *string  `json:"issn,omitempty"`
+	Issue               *string  `json:"issue,omitempty"`
+	Journal             *string  `json:"journal,omitempty"`
+	JournalAbbreviation *string  `json:"journal_abbreviation,omitempty"`
+	Keywords            []string `json:"keywords"`
+	Notes               *string  `json:"notes,omitempty"`
+	Pages               *string  `json:"pages,omitempty"`
+	Pii                 *string  `json:"pii,omitempty"`
+	Pmcid               *string  `json:"pmcid,omitempty"`
+
+	// PublishedDate The first day of the month or year when the precision is month or year
+	PublishedDate          *openapi_types.Date          `json:"published_date,omitempty"`
+	PublishedDatePrecision *PaperPublishedDatePrecision `json:"published_date_precision,omitempty"`
+	Publisher              *string                      `json:"publisher,omitempty"`
+	PubmedId               *int                         `json:"pubmed_id,omitempty"`
+	ScreenId               int64                        `json:"screen_id"`
+	Slug                   string                       `json:"slug"`
+	Title                  string                       `json:"title"`
+	UpdatedAt              string                       `json:"updated_at"`
+	UserId                 int64                        `json:"user_id"`
+	Volume                 *string                      `json:"volume,omitempty"`
+	Year                   *string                      `json:"year,omitempty"`
+}
+
+// PaperPublishedDatePrecision defines model for Paper.PublishedDatePrecision.
+type PaperPublishedDatePrecision string
+
+// PaperAuthor defines model for PaperAuthor.
+type PaperAuthor struct {
+	Affiliations  []Affiliation `json:"affiliations"`
+	AuthorId      int64         `json:"author_id"`
+	Corresponding bool          `json:"corresponding"`
+	Email         *string       `json:"email,omitempty"`
+	Forename      *string       `json:"forename,omitempty"`
+	Orcid         *string       `json:"orcid,omitempty"`
+	Order         int64         `json:"order"`
+	Surname       *string       `json:"surname,omitempty"`
+}
+
+// PaperDetail defines model for PaperDetail.
+type PaperDetail struct {
+	Abstract            string         `json:"abstract"`
+	AbstractParts       []AbstractPart `json:"abstract_parts"`
+	ArxivId             *string        `json:"arxiv_id,omitempty"`
+	Authors             []PaperAuthor  `json:"authors"`
+	CreatedAt           string         `json:"created_at"`
+	CustomKey           *string        `json:"custom_key,omitempty"`
+	Doi                 *string        `json:"doi,omitempty"`
+	Eissn               *string        `json:"eissn,omitempty"`
+	Id                  int64          `json:"id"`
+	Isbn                *string        `json:"isbn,omitempty"`
+	Issn                *string        `json:"issn,omitempty"`
+	Issue               *string        `json:"issue,omitempty"`
+	Journal             *string        `json:"journal,omitempty"`
+	JournalAbbreviation *string        `json:"journal_abbreviation,omitempty"`
+	Keywords            []string       `json:"keywords"`
+	Notes               *string        `json:"notes,omitempty"`
+	Pages               *string        `json:"pages,omitempty"`
+	Pii                 *string        `json:"pii,omitempty"`
+	Pmcid               *string        `json:"pmcid,omitempty"`
+
+	// PublishedDate The first day of the month or year when the precision is month or year
+	PublishedDate          *openapi_types.Date                `json:"published_date,omitempty"`
+	PublishedDatePrecision *PaperDetailPublishedDatePrecision `json:"published_date_precision,omitempty"`
+	Publisher              *string                            `json:"publisher,omitempty"`
+	PubmedId               *int                               `json:"pubmed_id,omitempty"`
+	ScreenId               int64                              `json:"screen_id"`
+	Sections               []Section                          `json:"sections"`
+	Slug                   string                             `json:"slug"`
+	Title                  string                             `json:"title"`
+	UpdatedAt              string                             `json:"updated_at"`
+	UserId                 int64                              `json:"user_id"`
+	Volume                 *string                            `json:"volume,omitempty"`
+	Year                   *string                            `json:"year,omitempty"`
+}
+
+// PaperDetailPublishedDatePrecision defines model for PaperDetail.PublishedDatePrecision.
+type PaperDetailPublishedDatePrecision string
+
+// PaperPage defines model for PaperPage.
+type PaperPage struct {
+	CurrentPage int     `json:"current_page"`
+	Data        []Paper `json:"data"`
+	LastPage    int     `json:"last_page"`
+	PerPage     int     `json:"per_page"`
+	Total       int64   `json:"total"`
+}
+
+// ParsedAbstractPart defines model for ParsedAbstractPart.
+type ParsedAbstractPart struct {
+	// Label Such as Background or Methods, empty for unstructured abstracts
+	Label string `json:"label"`
+	Text  string `json:"text"`
+}
+
+// ParsedAuthor defines model for ParsedAuthor.
+type ParsedAuthor struct {
+	Affiliations *[]Affiliation `json:"affiliations"`
+
+	// Corresponding Whether the paper marks the author as the corresponding author
+	Corresponding bool   `json:"corresponding"`
+	Email         string `json:"email"`
+	Forename      string `json:"forename"`
+	Orcid         string `json:"orcid"`
+	Surname       string `json:"surname"`
+}
+
+// ParsedFigure defines model for ParsedFigure.
+type ParsedFigure struct {
+	Caption string `json:"caption"`
+	Coords  *[]Box `json:"coords"`
+
+	// Label The printed label, such as "Table 1"
+	Label string `json:"label"`
+
+	// Rows The cells of a table. A cell spanning columns is followed by empty cells so the columns line up.
+	Rows  *[][]string      `json:"rows"`
+	TeiId string           `json:"tei_id"`
+	Type  ParsedFigureType `json:"type"`
+}
+
+// ParsedFigureType defines model for ParsedFigure.Type.
+type ParsedFigureType string
+
+// ParsedIdentifiers Normalised identifiers, empty when the PDF does not have them
+type ParsedIdentifiers struct {
+	// Arxiv Without its version
+	Arxiv string `json:"arxiv"`
+
+	// Doi Lower case, without a resolver prefix
+	Doi  string `json:"doi"`
+	Isbn string `json:"isbn"`
+
+	// Pii Upper case without punctuation
+	Pii string `json:"pii"`
+
+	// Pmcid With its PMC prefix
+	Pmcid string `json:"pmcid"`
+	Pmid  string `json:"pmid"`
+}
+
+// ParsedParagraph defines model for ParsedParagraph.
+type ParsedParagraph struct {
+	// Citations The citation markers taken out of the text
+	Citations *[]struct {
+		Marker string `json:"marker"`
+
+		// Offset Where the marker was in the text, in characters
+		Offset int `json:"offset"`
+
+		// Target The tei_id of the cited reference, empty when Grobid could not match the marker
+		Target string `json:"target"`
+	} `json:"citations"`
+	Coords    *[]Box `json:"coords"`
+	Sentences *[]struct {
+		Coords *[]Box `json:"coords"`
+		Text   string `json:"text"`
+	} `json:"sentences"`
+	Text string `json:"text"`
+}
+
+// ParsedReference defines model for ParsedReference.
+type ParsedReference struct {
+	Authors *[]string `json:"authors"`
+	Coords  *[]Box    `json:"coords"`
+	Doi     string    `json:"doi"`
+
+	// Raw The citation as printed in the paper
+	Raw string `json:"raw"`
+
+	// TeiId The xml:id of the entry in the TEI bibliography, which inline citations point to
+	TeiId string `json:"tei_id"`
+	Title string `json:"title"`
+	Venue string `json:"venue"`
+	Year  string `json:"year"`
+}
+
+// ParsedSection defines model for ParsedSection.
+type ParsedSection struct {
+	// Depth 1 for top level sections
+	Depth int    `json:"depth"`
+	Head  string `json:"head"`
+
+	// Number The printed section number, such as "2.3.1"
+	Number     string             `json:"number"`
+	Paragraphs *[]ParsedParagraph `json:"paragraphs"`
+
+	// Parent The index of the enclosing section in the list, -1 for top level sections
+	Parent int `json:"parent"`
+}
+
+// PoolStatus defines model for PoolStatus.
+type PoolStatus struct {
+	// Buffered Messages received by this instance that no worker has started
+	Buffered      int             `json:"buffered"`
+	Limits        Limits          `json:"limits"`
+	State         PoolStatusState `json:"state"`
+	TotalRequests int64           `json:"total_requests"`
+	WorkerCount   int             `json:"worker_count"`
+	Workers       []WorkerInfo    `json:"workers"`
+}
+
+// PoolStatusState defines model for PoolStatus.State.
+type PoolStatusState string
+
+// Progress defines model for Progress.
+type Progress struct {
+	Duplicate  int64    `json:"duplicate"`
+	EtaSeconds *float32 `json:"eta_seconds"`
+	Failed     int64    `json:"failed"`
+	InProgress int64    `json:"in_progress"`
+	Queued     int64    `json:"queued"`
+
+	// Remaining Papers the main app still counts as processing for the screen
+	Remaining int64 `json:"remaining"`
+	ScreenId  int64 `json:"screen_id"`
+	Succeeded int64 `json:"succeeded"`
+}
+
+// PublishedDate defines model for PublishedDate.
+type PublishedDate struct {
+	// Day Omitted when the precision is year or month
+	Day *int `json:"day,omitempty"`
+
+	// Month Omitted when the precision is year
+	Month     *int                   `json:"month,omitempty"`
+	Precision PublishedDatePrecision `json:"precision"`
+	Year      int                    `json:"year"`
+}
+
+// PublishedDatePrecision defines model for PublishedDate.Precision.
+type PublishedDatePrecision string
+
+// Readiness defines model for Readiness.
+type Readiness struct {
+	Checks map[string]CheckResult `json:"checks"`
+	Status ReadinessStatus        `json:"status"`
+}
+
+// ReadinessStatus defines model for Readiness.Status.
+type ReadinessStatus string
+
+// Reference defines model for Reference.
+type Reference struct {
+	Authors []string `json:"authors"`
+
+	// Citations Where the paper cites the reference
+	Citations []Citation `json:"citations"`
+	Coords    []Box      `json:"coords"`
+	CreatedAt string     `json:"created_at"`
+	Doi       *string    `json:"doi,omitempty"`
+	Id        int64      `json:"id"`
+	Order     int64      `json:"order"`
+	PaperId   int64      `json:"paper_id"`
+	Raw       *string    `json:"raw,omitempty"`
+	TeiId     *string    `json:"tei_id,omitempty"`
+	Title     *string    `json:"title,omitempty"`
+	UpdatedAt string     `json:"updated_at"`
+	Venue     *string    `json:"venue,omitempty"`
+	Year      *string    `json:"year,omitempty"`
+}
+
+// ReprocessRequest defines model for ReprocessRequest.
+type ReprocessRequest struct {
+	// Source Rerun Grobid on the retained PDF, or only reparse the retained TEI
+	Source *ReprocessRequestSource `json:"source,omitempty"`
+}
+
+// ReprocessRequestSource Rerun Grobid on the retained PDF, or only reparse the retained TEI
+type ReprocessRequestSource string
+
+// ReprocessResponse defines model for ReprocessResponse.
+type ReprocessResponse struct {
+	Error *string `json:"error,omitempty"`
+	Jobs  []Job   `json:"jobs"`
+}
+
+// Section defines model for Section.
+type Section struct {
+	// Coords Where the section is in the PDF, empty when Grobid did not place it
+	Coords    []Box  `json:"coords"`
+	CreatedAt string `json:"created_at"`
+
+	// Depth 1 for top level sections
+	Depth     int64   `json:"depth"`
+	Embedding *string `json:"embedding,omitempty"`
+	Header    string  `json:"header"`
+	Id        int64   `json:"id"`
+	Number    *string `json:"number,omitempty"`
+	Order     int64   `json:"order"`
+
+	// Page The page the section starts on
+	Page    *int64 `json:"page,omitempty"`
+	PaperId int64  `json:"paper_id"`
+
+	// ParentSectionId The first section of the enclosing section, a section with subsections but no paragraphs has one holding its heading
+	ParentSectionId *int64 `json:"parent_section_id,omitempty"`
+	Text            string `json:"text"`
+	UpdatedAt       string `json:"updated_at"`
+}
+
+// UploadManifest defines model for UploadManifest.
+type UploadManifest struct {
+	Error *string `json:"error,omitempty"`
+	Jobs  []struct {
+		JobId string `json:"job_id"`
+
+		// Name Path of the PDF in the archive
+		Name       string `json:"name"`
+		S3Location string `json:"s3_location"`
+	} `json:"jobs"`
+	Rejected []struct {
+		// DuplicateOf The entry with the same content when the reason is duplicate
+		DuplicateOf *string                      `json:"duplicate_of,omitempty"`
+		Name        string                       `json:"name"`
+		Reason      UploadManifestRejectedReason `json:"reason"`
+	} `json:"rejected"`
+	ScreenId int64 `json:"screen_id"`
+}
+
+// UploadManifestRejectedReason defines model for UploadManifest.Rejected.Reason.
+type UploadManifestRejectedReason string
+
+// WorkerCountUpdate defines model for WorkerCountUpdate.
+type WorkerCountUpdate struct {
+	Count int `json:"count"`
+}
+
+// WorkerInfo defines model for WorkerInfo.
+type WorkerInfo struct {
+	Busy           bool       `json:"busy"`
+	ElapsedSeconds float32    `json:"elapsed_seconds"`
+	Id             int        `json:"id"`
+	LastHeartbeat  time.Time  `json:"last_heartbeat"`
+	MessageId      *string    `json:"message_id,omitempty"`
+	S3Location     *string    `json:"s3_location,omitempty"`
+	Stage          *string    `json:"stage,omitempty"`
+	StartedAt      *time.Time `json:"started_at,omitempty"`
+	Stopping       bool       `json:"stopping"`
+}
+
+// LastEventID defines model for LastEventID.
+type LastEventID = string
+
+// LogLevel defines model for LogLevel.
+type LogLevel = string
+
+// LogSinceID defines model for LogSinceID.
+type LogSinceID = int64
+
+// LogStage defines model for LogStage.
+type LogStage = string
+
+// PaperID defines model for PaperID.
+type PaperID = int64
+
+// ScreenID defines model for ScreenID.
+type ScreenID = int64
+
+// UserID defines model for UserID.
+type UserID = int64
+
+// BadRequest defines model for BadRequest.
+type BadRequest = Error
+
+// Conflict defines model for Conflict.
+type Conflict = Error
+
+// Forbidden defines model for Forbidden.
+type Forbidden = Error
+
+// InternalError defines model for InternalError.
+type InternalError = Error
+
+// NotFound defines model for NotFound.
+type NotFound = Error
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = Error
+
+// ParsePDFMultipartBody defines parameters for ParsePDF.
+type ParsePDFMultipartBody struct {
+	// ConsolidateCitations 0 for none, 1 for full consolidation, 2 to only add the DOI found, 3 to consolidate from the extracted DOI only
+	ConsolidateCitations *ConsolidationLevel `json:"consolidate_citations,omitempty"`
+
+	// ConsolidateHeader 0 for none, 1 for full consolidation, 2 to only add the DOI found, 3 to consolidate from the extracted DOI only
+	ConsolidateHeader      *ConsolidationLevel `json:"consolidate_header,omitempty"`
+	End                    *int                `json:"end,omitempty"`
+	IncludeRawAffiliations *bool               `json:"include_raw_affiliations,omitempty"`
+	IncludeRawCitations    *bool               `json:"include_raw_citations,omitempty"`
+	Input                  openapi_types.File  `json:"input"`
+	SegmentSentences       *bool               `json:"segment_sentences,omitempty"`
+	Start                  *int                `json:"start,omitempty"`
+
+	// TeiCoordinates One value per element, a single empty value turns coordinates off
+	TeiCoordinates *[]string `json:"tei_coordinates,omitempty"`
+}
+
+// StreamScreenLogsParams defines parameters for StreamScreenLogs.
+type StreamScreenLogsParams struct {
+	// Level Comma separated levels to include, such as `error,warning`
+	Level *LogLevel `form:"level,omitempty" json:"level,omitempty"`
+
+	// Stage Only entries from this stage, such as `pdf_processing`
+	Stage *LogStage `form:"stage,omitempty" json:"stage,omitempty"`
+
+	// SinceId Start after this log id instead of the newest entry
+	SinceId *LogSinceID `form:"since_id,omitempty" json:"since_id,omitempty"`
+
+	// LastEventID Sent by reconnecting clients to resume after the last event they received
+	LastEventID *LastEventID `json:"Last-Event-ID,omitempty"`
+}
+
+// ListScreenPapersParams defines parameters for ListScreenPapers.
+type ListScreenPapersParams struct {
+	Page    *int `form:"page,omitempty" json:"page,omitempty"`
+	PerPage *int `form:"per_page,omitempty" json:"per_page,omitempty"`
+
+	// Doi Exact DOI
+	Doi *string `form:"doi,omitempty" json:"doi,omitempty"`
+
+	// Title Part of the title
+	Title *string `form:"title,omitempty" json:"title,omitempty"`
+	Year  *string `form:"year,omitempty" json:"year,omitempty"`
+}
+
+// UploadArchiveParams defines parameters for UploadArchive.
+type UploadArchiveParams struct {
+	UserId int64 `form:"user_id" json:"user_id"`
+}
+
+// StreamUserLogsParams defines parameters for StreamUserLogs.
+type StreamUserLogsParams struct {
+	// Level Comma separated levels to include, such as `error,warning`
+	Level *LogLevel `form:"level,omitempty" json:"level,omitempty"`
+
+	// Stage Only entries from this stage, such as `pdf_processing`
+	Stage *LogStage `form:"stage,omitempty" json:"stage,omitempty"`
+
+	// SinceId Start after this log id instead of the newest entry
+	SinceId *LogSinceID `form:"since_id,omitempty" json:"since_id,omitempty"`
+
+	// LastEventID Sent by reconnecting clients to resume after the last event they received
+	LastEventID *LastEventID `json:"Last-Event-ID,omitempty"`
+}
+
+// SetLimitsJSONRequestBody defines body for SetLimits for application/json ContentType.
+type SetLimitsJSONRequestBody = LimitsUpdate
+
+// SetWorkerCountJSONRequestBody defines body for SetWorkerCount for application/json ContentType.
+type SetWorkerCountJSONRequestBody = WorkerCountUpdate
+
+// EnqueueJobJSONRequestBody defines body for EnqueueJob for application/json ContentType.
+type EnqueueJobJSONRequestBody = EnqueueRequest
+
+// ReprocessPaperJSONRequestBody defines body for ReprocessPaper for application/json ContentType.
+type ReprocessPaperJSONRequestBody = ReprocessRequest
+
+// ParsePDFMultipartRequestBody defines body for ParsePDF for multipart/form-data ContentType.
+type ParsePDFMultipartRequestBody ParsePDFMultipartBody
+
+// SetScreenGrobidOptionsJSONRequestBody defines body for SetScreenGrobidOptions for application/json ContentType.
+type SetScreenGrobidOptionsJSONRequestBody = GrobidOptions
+
+// ReprocessScreenJSONRequestBody defines body for ReprocessScreen for application/json ContentType.
+type ReprocessScreenJSONRequestBody = ReprocessRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetHostname request
+	GetHostname(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DrainPool request
+	DrainPool(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetLimitsWithBody request with any body
+	SetLimitsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetLimits(ctx context.Context, body SetLimitsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PausePool request
+	PausePool(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ResumePool request
+	ResumePool(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPoolStatus request
+	GetPoolStatus(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWorkers request
+	GetWorkers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetWorkerCountWithBody request with any body
+	SetWorkerCountWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetWorkerCount(ctx context.Context, body SetWorkerCountJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHealth request
+	GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// EnqueueJobWithBody request with any body
+	EnqueueJobWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	EnqueueJob(ctx context.Context, body EnqueueJobJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetJob request
+	GetJob(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLivez request
+	GetLivez(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetMetrics request
+	GetMetrics(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOpenAPI request
+	GetOpenAPI(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPaper request
+	GetPaper(ctx context.Context, id PaperID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListPaperFigures request
+	ListPaperFigures(ctx context.Context, id PaperID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTableCSV request
+	GetTableCSV(ctx context.Context, id PaperID, figureId int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListPaperReferences request
+	ListPaperReferences(ctx context.Context, id PaperID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReprocessPaperWithBody request with any body
+	ReprocessPaperWithBody(ctx context.Context, id PaperID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ReprocessPaper(ctx context.Context, id PaperID, body ReprocessPaperJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ParsePDFWithBody request with any body
+	ParsePDFWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetReadyz request
+	GetReadyz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetScreenGrobidOptions request
+	GetScreenGrobidOptions(ctx context.Context, id ScreenID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetScreenGrobidOptionsWithBody request with any body
+	SetScreenGrobidOptionsWithBody(ctx context.Context, id ScreenID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetScreenGrobidOptions(ctx context.Context, id ScreenID, body SetScreenGrobidOptionsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StreamScreenLogs request
+	StreamScreenLogs(ctx context.Context, id ScreenID, params *StreamScreenLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListScreenPapers request
+	ListScreenPapers(ctx context.Context, id ScreenID, params *ListScreenPapersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetScreenProgress request
+	GetScreenProgress(ctx context.Context, id ScreenID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StreamScreenProgress request
+	StreamScreenProgress(ctx context.Context, id ScreenID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReprocessScreenWithBody request with any body
+	ReprocessScreenWithBody(ctx context.Context, id ScreenID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ReprocessScreen(ctx context.Context, id ScreenID, body ReprocessScreenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UploadArchiveWithBody request with any body
+	UploadArchiveWithBody(ctx context.Context, id ScreenID, params *UploadArchiveParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StreamUserLogs request
+	StreamUserLogs(ctx context.Context, id UserID, params *StreamUserLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetHostname(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHostnameRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DrainPool(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDrainPoolRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetLimitsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetLimitsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetLimits(ctx context.Context, body SetLimitsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetLimitsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PausePool(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPausePoolRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ResumePool(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewResumePoolRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPoolStatus(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPoolStatusRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWorkers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWorkersRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetWorkerCountWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetWorkerCountRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetWorkerCount(ctx context.Context, body SetWorkerCountJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetWorkerCountRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) EnqueueJobWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEnqueueJobRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) EnqueueJob(ctx context.Context, body EnqueueJobJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEnqueueJobRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetJob(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetJobRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetLivez(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLivezRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetMetrics(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetMetricsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetOpenAPI(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOpenAPIRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPaper(ctx context.Context, id PaperID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPaperRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListPaperFigures(ctx context.Context, id PaperID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPaperFiguresRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTableCSV(ctx context.Context, id PaperID, figureId int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTableCSVRequest(c.Server, id, figureId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListPaperReferences(ctx context.Context, id PaperID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPaperReferencesRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReprocessPaperWithBody(ctx context.Context, id PaperID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReprocessPaperRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReprocessPaper(ctx context.Context, id PaperID, body ReprocessPaperJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReprocessPaperRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ParsePDFWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewParsePDFRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetReadyz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetReadyzRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetScreenGrobidOptions(ctx context.Context, id ScreenID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetScreenGrobidOptionsRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetScreenGrobidOptionsWithBody(ctx context.Context, id ScreenID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetScreenGrobidOptionsRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetScreenGrobidOptions(ctx context.Context, id ScreenID, body SetScreenGrobidOptionsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetScreenGrobidOptionsRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StreamScreenLogs(ctx context.Context, id ScreenID, params *StreamScreenLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStreamScreenLogsRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListScreenPapers(ctx context.Context, id ScreenID, params *ListScreenPapersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListScreenPapersRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetScreenProgress(ctx context.Context, id ScreenID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetScreenProgressRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StreamScreenProgress(ctx context.Context, id ScreenID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStreamScreenProgressRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReprocessScreenWithBody(ctx context.Context, id ScreenID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReprocessScreenRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReprocessScreen(ctx context.Context, id ScreenID, body ReprocessScreenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReprocessScreenRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UploadArchiveWithBody(ctx context.Context, id ScreenID, params *UploadArchiveParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUploadArchiveRequestWithBody(c.Server, id, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StreamUserLogs(ctx context.Context, id UserID, params *StreamUserLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStreamUserLogsRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetHostnameRequest generates requests for GetHostname
+func NewGetHostnameRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDrainPoolRequest generates requests for DrainPool
+func NewDrainPoolRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/drain")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetLimitsRequest calls the generic SetLimits builder with application/json body
+func NewSetLimitsRequest(server string, body SetLimitsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetLimitsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewSetLimitsRequestWithBody generates requests for SetLimits with any type of body
+func NewSetLimitsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/limits")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPausePoolRequest generates requests for PausePool
+func NewPausePoolRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/pause")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewResumePoolRequest generates requests for ResumePool
+func NewResumePoolRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/resume")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPoolStatusRequest generates requests for GetPoolStatus
+func NewGetPoolStatusRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/status")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetWorkersRequest generates requests for GetWorkers
+func NewGetWorkersRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/workers")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetWorkerCountRequest calls the generic SetWorkerCount builder with application/json body
+func NewSetWorkerCountRequest(server string, body SetWorkerCountJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetWorkerCountRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewSetWorkerCountRequestWithBody generates requests for SetWorkerCount with any type of body
+func NewSetWorkerCountRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/workers")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetHealthRequest generates requests for GetHealth
+func NewGetHealthRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/health")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewEnqueueJobRequest calls the generic EnqueueJob builder with application/json body
+func NewEnqueueJobRequest(server string, body EnqueueJobJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewEnqueueJobRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewEnqueueJobRequestWithBody generates requests for EnqueueJob with any type of body
+func NewEnqueueJobRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/jobs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetJobRequest generates requests for GetJob
+func NewGetJobRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/jobs/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetLivezRequest generates requests for GetLivez
+func NewGetLivezRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/livez")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetMetricsRequest generates requests for GetMetrics
+func NewGetMetricsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/metrics")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetOpenAPIRequest generates requests for GetOpenAPI
+func NewGetOpenAPIRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/openapi.json")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPaperRequest generates requests for GetPaper
+func NewGetPaperRequest(server string, id PaperID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/papers/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListPaperFiguresRequest generates requests for ListPaperFigures
+func NewListPaperFiguresRequest(server string, id PaperID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/papers/%s/figures", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTableCSVRequest generates requests for GetTableCSV
+func NewGetTableCSVRequest(server string, id PaperID, figureId int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "figure_id", runtime.ParamLocationPath, figureId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/papers/%s/figures/%s/csv", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListPaperReferencesRequest generates requests for ListPaperReferences
+func NewListPaperReferencesRequest(server string, id PaperID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/papers/%s/references", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReprocessPaperRequest calls the generic ReprocessPaper builder with application/json body
+func NewReprocessPaperRequest(server string, id PaperID, body ReprocessPaperJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewReprocessPaperRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewReprocessPaperRequestWithBody generates requests for ReprocessPaper with any type of body
+func NewReprocessPaperRequestWithBody(server string, id PaperID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/papers/%s/reprocess", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewParsePDFRequestWithBody generates requests for ParsePDF with any type of body
+func NewParsePDFRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/parse")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetReadyzRequest generates requests for GetReadyz
+func NewGetReadyzRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/readyz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetScreenGrobidOptionsRequest generates requests for GetScreenGrobidOptions
+func NewGetScreenGrobidOptionsRequest(server string, id ScreenID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/screens/%s/grobid-options", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetScreenGrobidOptionsRequest calls the generic SetScreenGrobidOptions builder with application/json body
+func NewSetScreenGrobidOptionsRequest(server string, id ScreenID, body SetScreenGrobidOptionsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetScreenGrobidOptionsRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewSetScreenGrobidOptionsRequestWithBody generates requests for SetScreenGrobidOptions with any type of body
+func NewSetScreenGrobidOptionsRequestWithBody(server string, id ScreenID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/screens/%s/grobid-options", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewStreamScreenLogsRequest generates requests for StreamScreenLogs
+func NewStreamScreenLogsRequest(server string, id ScreenID, params *StreamScreenLogsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/screens/%s/logs/stream", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Level != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "level", runtime.ParamLocationQuery, *params.Level); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Stage != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "stage", runtime.ParamLocationQuery, *params.Stage); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.SinceId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since_id", runtime.ParamLocationQuery, *params.SinceId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.LastEventID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, *params.LastEventID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Last-Event-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewListScreenPapersRequest generates requests for ListScreenPapers
+func NewListScreenPapersRequest(server string, id ScreenID, params *ListScreenPapersParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/screens/%s/papers", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Page != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page", runtime.ParamLocationQuery, *params.Page); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PerPage != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "per_page", runtime.ParamLocationQuery, *params.PerPage); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Doi != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "doi", runtime.ParamLocationQuery, *params.Doi); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Title != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "title", runtime.ParamLocationQuery, *params.Title); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Year != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "year", runtime.ParamLocationQuery, *params.Year); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetScreenProgressRequest generates requests for GetScreenProgress
+func NewGetScreenProgressRequest(server string, id ScreenID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/screens/%s/progress", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStreamScreenProgressRequest generates requests for StreamScreenProgress
+func NewStreamScreenProgressRequest(server string, id ScreenID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/screens/%s/progress/stream", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReprocessScreenRequest calls the generic ReprocessScreen builder with application/json body
+func NewReprocessScreenRequest(server string, id ScreenID, body ReprocessScreenJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewReprocessScreenRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewReprocessScreenRequestWithBody generates requests for ReprocessScreen with any type of body
+func NewReprocessScreenRequestWithBody(server string, id ScreenID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/screens/%s/reprocess", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUploadArchiveRequestWithBody generates requests for UploadArchive with any type of body
+func NewUploadArchiveRequestWithBody(server string, id ScreenID, params *UploadArchiveParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/screens/%s/uploads", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewStreamUserLogsRequest generates requests for StreamUserLogs
+func NewStreamUserLogsRequest(server string, id UserID, params *StreamUserLogsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/%s/logs/stream", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Level != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "level", runtime.ParamLocationQuery, *params.Level); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Stage != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "stage", runtime.ParamLocationQuery, *params.Stage); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.SinceId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since_id", runtime.ParamLocationQuery, *params.SinceId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.LastEventID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, *params.LastEventID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Last-Event-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetHostnameWithResponse request
+	GetHostnameWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHostnameResponse, error)
+
+	// DrainPoolWithResponse request
+	DrainPoolWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DrainPoolResponse, error)
+
+	// SetLimitsWithBodyWithResponse request with any body
+	SetLimitsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetLimitsResponse, error)
+
+	SetLimitsWithResponse(ctx context.Context, body SetLimitsJSONRequestBody, reqEditors ...RequestEditorFn) (*SetLimitsResponse, error)
+
+	// PausePoolWithResponse request
+	PausePoolWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PausePoolResponse, error)
+
+	// ResumePoolWithResponse request
+	ResumePoolWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ResumePoolResponse, error)
+
+	// GetPoolStatusWithResponse request
+	GetPoolStatusWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPoolStatusResponse, error)
+
+	// GetWorkersWithResponse request
+	GetWorkersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWorkersResponse, error)
+
+	// SetWorkerCountWithBodyWithResponse request with any body
+	SetWorkerCountWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetWorkerCountResponse, error)
+
+	SetWorkerCountWithResponse(ctx context.Context, body SetWorkerCountJSONRequestBody, reqEditors ...RequestEditorFn) (*SetWorkerCountResponse, error)
+
+	// GetHealthWithResponse request
+	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+
+	// EnqueueJobWithBodyWithResponse request with any body
+	EnqueueJobWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*EnqueueJobResponse, error)
+
+	EnqueueJobWithResponse(ctx context.Context, body EnqueueJobJSONRequestBody, reqEditors ...RequestEditorFn) (*EnqueueJobResponse, error)
+
+	// GetJobWithResponse request
+	GetJobWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetJobResponse, error)
+
+	// GetLivezWithResponse request
+	GetLivezWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLivezResponse, error)
+
+	// GetMetricsWithResponse request
+	GetMetricsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetMetricsResponse, error)
+
+	// GetOpenAPIWithResponse request
+	GetOpenAPIWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOpenAPIResponse, error)
+
+	// GetPaperWithResponse request
+	GetPaperWithResponse(ctx context.Context, id PaperID, reqEditors ...RequestEditorFn) (*GetPaperResponse, error)
+
+	// ListPaperFiguresWithResponse request
+	ListPaperFiguresWithResponse(ctx context.Context, id PaperID, reqEditors ...RequestEditorFn) (*ListPaperFiguresResponse, error)
+
+	// GetTableCSVWithResponse request
+	GetTableCSVWithResponse(ctx context.Context, id PaperID, figureId int64, reqEditors ...RequestEditorFn) (*GetTableCSVResponse, error)
+
+	// ListPaperReferencesWithResponse request
+	ListPaperReferencesWithResponse(ctx context.Context, id PaperID, reqEditors ...RequestEditorFn) (*ListPaperReferencesResponse, error)
+
+	// ReprocessPaperWithBodyWithResponse request with any body
+	ReprocessPaperWithBodyWithResponse(ctx context.Context, id PaperID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReprocessPaperResponse, error)
+
+	ReprocessPaperWithResponse(ctx context.Context, id PaperID, body ReprocessPaperJSONRequestBody, reqEditors ...RequestEditorFn) (*ReprocessPaperResponse, error)
+
+	// ParsePDFWithBodyWithResponse request with any body
+	ParsePDFWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ParsePDFResponse, error)
+
+	// GetReadyzWithResponse request
+	GetReadyzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetReadyzResponse, error)
+
+	// GetScreenGrobidOptionsWithResponse request
+	GetScreenGrobidOptionsWithResponse(ctx context.Context, id ScreenID, reqEditors ...RequestEditorFn) (*GetScreenGrobidOptionsResponse, error)
+
+	// SetScreenGrobidOptionsWithBodyWithResponse request with any body
+	SetScreenGrobidOptionsWithBodyWithResponse(ctx context.Context, id ScreenID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetScreenGrobidOptionsResponse, error)
+
+	SetScreenGrobidOptionsWithResponse(ctx context.Context, id ScreenID, body SetScreenGrobidOptionsJSONRequestBody, reqEditors ...RequestEditorFn) (*SetScreenGrobidOptionsResponse, error)
+
+	// StreamScreenLogsWithResponse request
+	StreamScreenLogsWithResponse(ctx context.Context, id ScreenID, params *StreamScreenLogsParams, reqEditors ...RequestEditorFn) (*StreamScreenLogsResponse, error)
+
+	// ListScreenPapersWithResponse request
+	ListScreenPapersWithResponse(ctx context.Context, id ScreenID, params *ListScreenPapersParams, reqEditors ...RequestEditorFn) (*ListScreenPapersResponse, error)
+
+	// GetScreenProgressWithResponse request
+	GetScreenProgressWithResponse(ctx context.Context, id ScreenID, reqEditors ...RequestEditorFn) (*GetScreenProgressResponse, error)
+
+	// StreamScreenProgressWithResponse request
+	StreamScreenProgressWithResponse(ctx context.Context, id ScreenID, reqEditors ...RequestEditorFn) (*StreamScreenProgressResponse, error)
+
+	// ReprocessScreenWithBodyWithResponse request with any body
+	ReprocessScreenWithBodyWithResponse(ctx context.Context, id ScreenID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReprocessScreenResponse, error)
+
+	ReprocessScreenWithResponse(ctx context.Context, id ScreenID, body ReprocessScreenJSONRequestBody, reqEditors ...RequestEditorFn) (*ReprocessScreenResponse, error)
+
+	// UploadArchiveWithBodyWithResponse request with any body
+	UploadArchiveWithBodyWithResponse(ctx context.Context, id ScreenID, params *UploadArchiveParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UploadArchiveResponse, error)
+
+	// StreamUserLogsWithResponse request
+	StreamUserLogsWithResponse(ctx context.Context, id UserID, params *StreamUserLogsParams, reqEditors ...RequestEditorFn) (*StreamUserLogsResponse, error)
+}
+
+type GetHostnameResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Hostname string `json:"hostname"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHostnameResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHostnameResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DrainPoolResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PoolStatus
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON409      *Conflict
+}
+
+// Status returns HTTPResponse.Status
+func (r DrainPoolResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DrainPoolResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetLimitsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PoolStatus
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+}
+
+// Status returns HTTPResponse.Status
+func (r SetLimitsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetLimitsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PausePoolResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PoolStatus
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON409      *Conflict
+}
+
+// Status returns HTTPResponse.Status
+func (r PausePoolResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PausePoolResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ResumePoolResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PoolStatus
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON409      *Conflict
+}
+
+// Status returns HTTPResponse.Status
+func (r ResumePoolResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ResumePoolResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPoolStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PoolStatus
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPoolStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPoolStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWorkersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]WorkerInfo
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWorkersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWorkersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetWorkerCountResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PoolStatus
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+}
+
+// Status returns HTTPResponse.Status
+func (r SetWorkerCountResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetWorkerCountResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHealthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Healthy bool `json:"healthy"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type EnqueueJobResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *Job
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON502      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r EnqueueJobResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r EnqueueJobResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetJobResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Job
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r GetJobResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetJobResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLivezResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Liveness
+	JSON503      *Liveness
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLivezResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLivezResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetMetricsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetMetricsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetMetricsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOpenAPIResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOpenAPIResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOpenAPIResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPaperResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PaperDetail
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPaperResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPaperResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListPaperFiguresResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Figure
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPaperFiguresResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPaperFiguresResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTableCSVResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTableCSVResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTableCSVResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListPaperReferencesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Reference
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPaperReferencesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPaperReferencesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReprocessPaperResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *ReprocessResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON500      *InternalError
+	JSON502      *ReprocessResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ReprocessPaperResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReprocessPaperResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ParsePDFResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PDFDTO
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON422      *Error
+	JSON502      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ParsePDFResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ParsePDFResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetReadyzResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Readiness
+	JSON503      *Readiness
+}
+
+// Status returns HTTPResponse.Status
+func (r GetReadyzResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetReadyzResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetScreenGrobidOptionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GrobidOptions
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetScreenGrobidOptionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetScreenGrobidOptionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetScreenGrobidOptionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GrobidOptions
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r SetScreenGrobidOptionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetScreenGrobidOptionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StreamScreenLogsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r StreamScreenLogsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StreamScreenLogsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListScreenPapersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PaperPage
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r ListScreenPapersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListScreenPapersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetScreenProgressResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Progress
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetScreenProgressResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetScreenProgressResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StreamScreenProgressResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+}
+
+// Status returns HTTPResponse.Status
+func (r StreamScreenProgressResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StreamScreenProgressResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReprocessScreenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *ReprocessResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON500      *InternalError
+	JSON502      *ReprocessResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ReprocessScreenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReprocessScreenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UploadArchiveResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *UploadManifest
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON413      *Error
+	JSON502      *UploadManifest
+}
+
+// Status returns HTTPResponse.Status
+func (r UploadArchiveResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UploadArchiveResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StreamUserLogsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r StreamUserLogsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StreamUserLogsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetHostnameWithResponse request returning *GetHostnameResponse
+func (c *ClientWithResponses) GetHostnameWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHostnameResponse, error) {
+	rsp, err := c.GetHostname(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHostnameResponse(rsp)
+}
+
+// DrainPoolWithResponse request returning *DrainPoolResponse
+func (c *ClientWithResponses) DrainPoolWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DrainPoolResponse, error) {
+	rsp, err := c.DrainPool(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDrainPoolResponse(rsp)
+}
+
+// SetLimitsWithBodyWithResponse request with arbitrary body returning *SetLimitsResponse
+func (c *ClientWithResponses) SetLimitsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetLimitsResponse, error) {
+	rsp, err := c.SetLimitsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetLimitsResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetLimitsWithResponse(ctx context.Context, body SetLimitsJSONRequestBody, reqEditors ...RequestEditorFn) (*SetLimitsResponse, error) {
+	rsp, err := c.SetLimits(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetLimitsResponse(rsp)
+}
+
+// PausePoolWithResponse request returning *PausePoolResponse
+func (c *ClientWithResponses) PausePoolWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PausePoolResponse, error) {
+	rsp, err := c.PausePool(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePausePoolResponse(rsp)
+}
+
+// ResumePoolWithResponse request returning *ResumePoolResponse
+func (c *ClientWithResponses) ResumePoolWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ResumePoolResponse, error) {
+	rsp, err := c.ResumePool(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResumePoolResponse(rsp)
+}
+
+// GetPoolStatusWithResponse request returning *GetPoolStatusResponse
+func (c *ClientWithResponses) GetPoolStatusWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPoolStatusResponse, error) {
+	rsp, err := c.GetPoolStatus(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPoolStatusResponse(rsp)
+}
+
+// GetWorkersWithResponse request returning *GetWorkersResponse
+func (c *ClientWithResponses) GetWorkersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWorkersResponse, error) {
+	rsp, err := c.GetWorkers(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWorkersResponse(rsp)
+}
+
+// SetWorkerCountWithBodyWithResponse request with arbitrary body returning *SetWorkerCountResponse
+func (c *ClientWithResponses) SetWorkerCountWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetWorkerCountResponse, error) {
+	rsp, err := c.SetWorkerCountWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetWorkerCountResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetWorkerCountWithResponse(ctx context.Context, body SetWorkerCountJSONRequestBody, reqEditors ...RequestEditorFn) (*SetWorkerCountResponse, error) {
+	rsp, err := c.SetWorkerCount(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetWorkerCountResponse(rsp)
+}
+
+// GetHealthWithResponse request returning *GetHealthResponse
+func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
+	rsp, err := c.GetHealth(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthResponse(rsp)
+}
+
+// EnqueueJobWithBodyWithResponse request with arbitrary body returning *EnqueueJobResponse
+func (c *ClientWithResponses) EnqueueJobWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*EnqueueJobResponse, error) {
+	rsp, err := c.EnqueueJobWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEnqueueJobResponse(rsp)
+}
+
+func (c *ClientWithResponses) EnqueueJobWithResponse(ctx context.Context, body EnqueueJobJSONRequestBody, reqEditors ...RequestEditorFn) (*EnqueueJobResponse, error) {
+	rsp, err := c.EnqueueJob(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEnqueueJobResponse(rsp)
+}
+
+// GetJobWithResponse request returning *GetJobResponse
+func (c *ClientWithResponses) GetJobWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetJobResponse, error) {
+	rsp, err := c.GetJob(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetJobResponse(rsp)
+}
+
+// GetLivezWithResponse request returning *GetLivezResponse
+func (c *ClientWithResponses) GetLivezWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLivezResponse, error) {
+	rsp, err := c.GetLivez(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLivezResponse(rsp)
+}
+
+// GetMetricsWithResponse request returning *GetMetricsResponse
+func (c *ClientWithResponses) GetMetricsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetMetricsResponse, error) {
+	rsp, err := c.GetMetrics(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetMetricsResponse(rsp)
+}
+
+// GetOpenAPIWithResponse request returning *GetOpenAPIResponse
+func (c *ClientWithResponses) GetOpenAPIWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOpenAPIResponse, error) {
+	rsp, err := c.GetOpenAPI(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOpenAPIResponse(rsp)
+}
+
+// GetPaperWithResponse request returning *GetPaperResponse
+func (c *ClientWithResponses) GetPaperWithResponse(ctx context.Context, id PaperID, reqEditors ...RequestEditorFn) (*GetPaperResponse, error) {
+	rsp, err := c.GetPaper(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPaperResponse(rsp)
+}
+
+// ListPaperFiguresWithResponse request returning *ListPaperFiguresResponse
+func (c *ClientWithResponses) ListPaperFiguresWithResponse(ctx context.Context, id PaperID, reqEditors ...RequestEditorFn) (*ListPaperFiguresResponse, error) {
+	rsp, err := c.ListPaperFigures(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPaperFiguresResponse(rsp)
+}
+
+// GetTableCSVWithResponse request returning *GetTableCSVResponse
+func (c *ClientWithResponses) GetTableCSVWithResponse(ctx context.Context, id PaperID, figureId int64, reqEditors ...RequestEditorFn) (*GetTableCSVResponse, error) {
+	rsp, err := c.GetTableCSV(ctx, id, figureId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTableCSVResponse(rsp)
+}
+
+// ListPaperReferencesWithResponse request returning *ListPaperReferencesResponse
+func (c *ClientWithResponses) ListPaperReferencesWithResponse(ctx context.Context, id PaperID, reqEditors ...RequestEditorFn) (*ListPaperReferencesResponse, error) {
+	rsp, err := c.ListPaperReferences(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPaperReferencesResponse(rsp)
+}
+
+// ReprocessPaperWithBodyWithResponse request with arbitrary body returning *ReprocessPaperResponse
+func (c *ClientWithResponses) ReprocessPaperWithBodyWithResponse(ctx context.Context, id PaperID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReprocessPaperResponse, error) {
+	rsp, err := c.ReprocessPaperWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReprocessPaperResponse(rsp)
+}
+
+func (c *ClientWithResponses) ReprocessPaperWithResponse(ctx context.Context, id PaperID, body ReprocessPaperJSONRequestBody, reqEditors ...RequestEditorFn) (*ReprocessPaperResponse, error) {
+	rsp, err := c.ReprocessPaper(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReprocessPaperResponse(rsp)
+}
+
+// ParsePDFWithBodyWithResponse request with arbitrary body returning *ParsePDFResponse
+func (c *ClientWithResponses) ParsePDFWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ParsePDFResponse, error) {
+	rsp, err := c.ParsePDFWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseParsePDFResponse(rsp)
+}
+
+// GetReadyzWithResponse request returning *GetReadyzResponse
+func (c *ClientWithResponses) GetReadyzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetReadyzResponse, error) {
+	rsp, err := c.GetReadyz(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetReadyzResponse(rsp)
+}
+
+// GetScreenGrobidOptionsWithResponse request returning *GetScreenGrobidOptionsResponse
+func (c *ClientWithResponses) GetScreenGrobidOptionsWithResponse(ctx context.Context, id ScreenID, reqEditors ...RequestEditorFn) (*GetScreenGrobidOptionsResponse, error) {
+	rsp, err := c.GetScreenGrobidOptions(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetScreenGrobidOptionsResponse(rsp)
+}
+
+// SetScreenGrobidOptionsWithBodyWithResponse request with arbitrary body returning *SetScreenGrobidOptionsResponse
+func (c *ClientWithResponses) SetScreenGrobidOptionsWithBodyWithResponse(ctx context.Context, id ScreenID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetScreenGrobidOptionsResponse, error) {
+	rsp, err := c.SetScreenGrobidOptionsWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetScreenGrobidOptionsResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetScreenGrobidOptionsWithResponse(ctx context.Context, id ScreenID, body SetScreenGrobidOptionsJSONRequestBody, reqEditors ...RequestEditorFn) (*SetScreenGrobidOptionsResponse, error) {
+	rsp, err := c.SetScreenGrobidOptions(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetScreenGrobidOptionsResponse(rsp)
+}
+
+// StreamScreenLogsWithResponse request returning *StreamScreenLogsResponse
+func (c *ClientWithResponses) StreamScreenLogsWithResponse(ctx context.Context, id ScreenID, params *StreamScreenLogsParams, reqEditors ...RequestEditorFn) (*StreamScreenLogsResponse, error) {
+	rsp, err := c.StreamScreenLogs(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStreamScreenLogsResponse(rsp)
+}
+
+// ListScreenPapersWithResponse request returning *ListScreenPapersResponse
+func (c *ClientWithResponses) ListScreenPapersWithResponse(ctx context.Context, id ScreenID, params *ListScreenPapersParams, reqEditors ...RequestEditorFn) (*ListScreenPapersResponse, error) {
+	rsp, err := c.ListScreenPapers(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListScreenPapersResponse(rsp)
+}
+
+// GetScreenProgressWithResponse request returning *GetScreenProgressResponse
+func (c *ClientWithResponses) GetScreenProgressWithResponse(ctx context.Context, id ScreenID, reqEditors ...RequestEditorFn) (*GetScreenProgressResponse, error) {
+	rsp, err := c.GetScreenProgress(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetScreenProgressResponse(rsp)
+}
+
+// StreamScreenProgressWithResponse request returning *StreamScreenProgressResponse
+func (c *ClientWithResponses) StreamScreenProgressWithResponse(ctx context.Context, id ScreenID, reqEditors ...RequestEditorFn) (*StreamScreenProgressResponse, error) {
+	rsp, err := c.StreamScreenProgress(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStreamScreenProgressResponse(rsp)
+}
+
+// ReprocessScreenWithBodyWithResponse request with arbitrary body returning *ReprocessScreenResponse
+func (c *ClientWithResponses) ReprocessScreenWithBodyWithResponse(ctx context.Context, id ScreenID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReprocessScreenResponse, error) {
+	rsp, err := c.ReprocessScreenWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReprocessScreenResponse(rsp)
+}
+
+func (c *ClientWithResponses) ReprocessScreenWithResponse(ctx context.Context, id ScreenID, body ReprocessScreenJSONRequestBody, reqEditors ...RequestEditorFn) (*ReprocessScreenResponse, error) {
+	rsp, err := c.ReprocessScreen(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReprocessScreenResponse(rsp)
+}
+
+// UploadArchiveWithBodyWithResponse request with arbitrary body returning *UploadArchiveResponse
+func (c *ClientWithResponses) UploadArchiveWithBodyWithResponse(ctx context.Context, id ScreenID, params *UploadArchiveParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UploadArchiveResponse, error) {
+	rsp, err := c.UploadArchiveWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUploadArchiveResponse(rsp)
+}
+
+// StreamUserLogsWithResponse request returning *StreamUserLogsResponse
+func (c *ClientWithResponses) StreamUserLogsWithResponse(ctx context.Context, id UserID, params *StreamUserLogsParams, reqEditors ...RequestEditorFn) (*StreamUserLogsResponse, error) {
+	rsp, err := c.StreamUserLogs(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStreamUserLogsResponse(rsp)
+}
+
+// ParseGetHostnameResponse parses an HTTP response from a GetHostnameWithResponse call
+func ParseGetHostnameResponse(rsp *http.Response) (*GetHostnameResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHostnameResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Hostname string `json:"hostname"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDrainPoolResponse parses an HTTP response from a DrainPoolWithResponse call
+func ParseDrainPoolResponse(rsp *http.Response) (*DrainPoolResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DrainPoolResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PoolStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetLimitsResponse parses an HTTP response from a SetLimitsWithResponse call
+func ParseSetLimitsResponse(rsp *http.Response) (*SetLimitsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetLimitsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PoolStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePausePoolResponse parses an HTTP response from a PausePoolWithResponse call
+func ParsePausePoolResponse(rsp *http.Response) (*PausePoolResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PausePoolResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PoolStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseResumePoolResponse parses an HTTP response from a ResumePoolWithResponse call
+func ParseResumePoolResponse(rsp *http.Response) (*ResumePoolResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ResumePoolResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PoolStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPoolStatusResponse parses an HTTP response from a GetPoolStatusWithResponse call
+func ParseGetPoolStatusResponse(rsp *http.Response) (*GetPoolStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPoolStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PoolStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetWorkersResponse parses an HTTP response from a GetWorkersWithResponse call
+func ParseGetWorkersResponse(rsp *http.Response) (*GetWorkersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWorkersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []WorkerInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetWorkerCountResponse parses an HTTP response from a SetWorkerCountWithResponse call
+func ParseSetWorkerCountResponse(rsp *http.Response) (*SetWorkerCountResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetWorkerCountResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PoolStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
+func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHealthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Healthy bool `json:"healthy"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseEnqueueJobResponse parses an HTTP response from a EnqueueJobWithResponse call
+func ParseEnqueueJobResponse(rsp *http.Response) (*EnqueueJobResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &EnqueueJobResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Job
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetJobResponse parses an HTTP response from a GetJobWithResponse call
+func ParseGetJobResponse(rsp *http.Response) (*GetJobResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetJobResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Job
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetLivezResponse parses an HTTP response from a GetLivezWithResponse call
+func ParseGetLivezResponse(rsp *http.Response) (*GetLivezResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLivezResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Liveness
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Liveness
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetMetricsResponse parses an HTTP response from a GetMetricsWithResponse call
+func ParseGetMetricsResponse(rsp *http.Response) (*GetMetricsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetMetricsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetOpenAPIResponse parses an HTTP response from a GetOpenAPIWithResponse call
+func ParseGetOpenAPIResponse(rsp *http.Response) (*GetOpenAPIResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOpenAPIResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPaperResponse parses an HTTP response from a GetPaperWithResponse call
+func ParseGetPaperResponse(rsp *http.Response) (*GetPaperResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPaperResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PaperDetail
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListPaperFiguresResponse parses an HTTP response from a ListPaperFiguresWithResponse call
+func ParseListPaperFiguresResponse(rsp *http.Response) (*ListPaperFiguresResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListPaperFiguresResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Figure
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTableCSVResponse parses an HTTP response from a GetTableCSVWithResponse call
+func ParseGetTableCSVResponse(rsp *http.Response) (*GetTableCSVResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTableCSVResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListPaperReferencesResponse parses an HTTP response from a ListPaperReferencesWithResponse call
+func ParseListPaperReferencesResponse(rsp *http.Response) (*ListPaperReferencesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListPaperReferencesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Reference
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReprocessPaperResponse parses an HTTP response from a ReprocessPaperWithResponse call
+func ParseReprocessPaperResponse(rsp *http.Response) (*ReprocessPaperResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReprocessPaperResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest ReprocessResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest ReprocessResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseParsePDFResponse parses an HTTP response from a ParsePDFWithResponse call
+func ParseParsePDFResponse(rsp *http.Response) (*ParsePDFResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ParsePDFResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PDFDTO
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetReadyzResponse parses an HTTP response from a GetReadyzWithResponse call
+func ParseGetReadyzResponse(rsp *http.Response) (*GetReadyzResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetReadyzResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Readiness
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Readiness
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetScreenGrobidOptionsResponse parses an HTTP response from a GetScreenGrobidOptionsWithResponse call
+func ParseGetScreenGrobidOptionsResponse(rsp *http.Response) (*GetScreenGrobidOptionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetScreenGrobidOptionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GrobidOptions
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetScreenGrobidOptionsResponse parses an HTTP response from a SetScreenGrobidOptionsWithResponse call
+func ParseSetScreenGrobidOptionsResponse(rsp *http.Response) (*SetScreenGrobidOptionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetScreenGrobidOptionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GrobidOptions
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStreamScreenLogsResponse parses an HTTP response from a StreamScreenLogsWithResponse call
+func ParseStreamScreenLogsResponse(rsp *http.Response) (*StreamScreenLogsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StreamScreenLogsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListScreenPapersResponse parses an HTTP response from a ListScreenPapersWithResponse call
+func ParseListScreenPapersResponse(rsp *http.Response) (*ListScreenPapersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListScreenPapersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PaperPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetScreenProgressResponse parses an HTTP response from a GetScreenProgressWithResponse call
+func ParseGetScreenProgressResponse(rsp *http.Response) (*GetScreenProgressResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetScreenProgressResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Progress
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStreamScreenProgressResponse parses an HTTP response from a StreamScreenProgressWithResponse call
+func ParseStreamScreenProgressResponse(rsp *http.Response) (*StreamScreenProgressResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StreamScreenProgressResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReprocessScreenResponse parses an HTTP response from a ReprocessScreenWithResponse call
+func ParseReprocessScreenResponse(rsp *http.Response) (*ReprocessScreenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReprocessScreenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest ReprocessResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest ReprocessResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUploadArchiveResponse parses an HTTP response from a UploadArchiveWithResponse call
+func ParseUploadArchiveResponse(rsp *http.Response) (*UploadArchiveResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UploadArchiveResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest UploadManifest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 413:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON413 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest UploadManifest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStreamUserLogsResponse parses an HTTP response from a StreamUserLogsWithResponse call
+func ParseStreamUserLogsResponse(rsp *http.Response) (*StreamUserLogsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StreamUserLogsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
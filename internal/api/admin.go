@@ -0,0 +1,77 @@
+package api
+
+import (
+	"net/http"
+	"simple-go-app/internal/dispatcher"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type workerCountRequest struct {
+	Count *int `json:"count" binding:"required"`
+}
+
+type limitsRequest struct {
+	MinimumGapBetweenRequestsSeconds *float64 `json:"minimum_gap_between_requests_seconds"`
+	GracePeriodRequests              *int     `json:"grace_period_requests"`
+	GracePeriodWorkers               *int     `json:"grace_period_workers"`
+}
+
+func (s *Server) getPoolStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, s.Pool.Status())
+}
+
+func (s *Server) getWorkers(c *gin.Context) {
+	c.JSON(http.StatusOK, s.Pool.Status().Workers)
+}
+
+func (s *Server) setWorkerCount(c *gin.Context) {
+	var request workerCountRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := s.Pool.SetWorkerCount(*request.Count); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, s.Pool.Status())
+}
+
+// setLimits updates the rate limits, leaving any field missing from the request unchanged
+func (s *Server) setLimits(c *gin.Context) {
+	var request limitsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	limits := s.Pool.Status().Limits
+	if request.MinimumGapBetweenRequestsSeconds != nil {
+		limits.MinimumGapBetweenRequests = time.Duration(*request.MinimumGapBetweenRequestsSeconds * float64(time.Second))
+	}
+	if request.GracePeriodRequests != nil {
+		limits.GracePeriodRequests = *request.GracePeriodRequests
+	}
+	if request.GracePeriodWorkers != nil {
+		limits.GracePeriodWorkers = *request.GracePeriodWorkers
+	}
+
+	if err := s.Pool.SetLimits(limits); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, s.Pool.Status())
+}
+
+// poolAction wraps a state change of the pool, answering 409 if the pool is not in a state that allows it
+func (s *Server) poolAction(action func(*dispatcher.Pool) error) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := action(s.Pool); err != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, s.Pool.Status())
+	}
+}
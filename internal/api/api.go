@@ -0,0 +1,87 @@
+package api
+
+import (
+	"net/http"
+	"simple-go-app/internal/auth"
+	"simple-go-app/internal/dispatcher"
+	"simple-go-app/internal/health"
+	"simple-go-app/internal/helpers"
+	"simple-go-app/internal/jobs"
+	"simple-go-app/internal/parsing"
+	"simple-go-app/internal/store"
+	"simple-go-app/internal/uploads"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/gin-gonic/gin"
+)
+
+// Server holds the dependencies of the HTTP handlers
+type Server struct {
+	Tracker   *jobs.Tracker
+	Cache     *helpers.CacheHelper
+	Pool      *dispatcher.Pool
+	Store     *store.Store
+	Grobid    parsing.Grobid
+	Auth      *auth.Authenticator
+	Readiness *health.Checker
+	// StuckWorkerTimeout is how long a worker may spend on one message before liveness fails
+	StuckWorkerTimeout time.Duration
+	// LogPollInterval is how often log streams check for new entries
+	LogPollInterval time.Duration
+	// MaxUploadBytes is the largest PDF accepted by /parse
+	MaxUploadBytes int64
+
+	// S3 and S3Bucket are where PDFs extracted from uploaded archives are stored, under UploadPrefix
+	S3           *s3.S3
+	S3Bucket     string
+	UploadPrefix string
+	UploadLimits uploads.Limits
+}
+
+// Register adds the API routes to the router, each group requiring the scope its endpoints need
+// and validating requests against the OpenAPI spec
+func (s *Server) Register(r gin.IRouter) {
+	read := r.Group("", s.Auth.Require(auth.ScopeRead), validate)
+	read.GET("/openapi.json", s.getOpenAPI)
+	read.GET("/livez", s.getLivez)
+	read.GET("/readyz", s.getReadyz)
+	read.GET("/jobs/:id", s.getJob)
+	read.GET("/screens/:id/progress", s.getScreenProgress)
+	read.GET("/screens/:id/progress/stream", s.streamScreenProgress)
+	read.GET("/screens/:id/papers", s.listScreenPapers)
+	read.GET("/screens/:id/grobid-options", s.getScreenGrobidOptions)
+	read.GET("/papers/:id", s.getPaper)
+	read.GET("/papers/:id/references", s.listPaperReferences)
+	read.GET("/papers/:id/figures", s.listPaperFigures)
+	read.GET("/papers/:id/figures/:figure_id/csv", s.getTableCSV)
+	read.GET("/screens/:id/logs/stream", s.streamScreenLogs)
+	read.GET("/users/:id/logs/stream", s.streamUserLogs)
+
+	r.POST("/parse", s.Auth.RequireStream(auth.ScopeParse), validate, s.parsePDF)
+	r.POST("/jobs", s.Auth.Require(auth.ScopeEnqueue), validate, s.enqueueJob)
+	r.POST("/screens/:id/uploads", s.Auth.RequireStream(auth.ScopeEnqueue), validate, s.uploadArchive)
+	r.POST("/screens/:id/reprocess", s.Auth.Require(auth.ScopeEnqueue), validate, s.reprocessScreen)
+	r.POST("/papers/:id/reprocess", s.Auth.Require(auth.ScopeEnqueue), validate, s.reprocessPaper)
+	r.PUT("/screens/:id/grobid-options", s.Auth.Require(auth.ScopeAdmin), validate, s.setScreenGrobidOptions)
+
+	admin := r.Group("/admin", s.Auth.Require(auth.ScopeAdmin), validate)
+	admin.GET("/status", s.getPoolStatus)
+	admin.GET("/workers", s.getWorkers)
+	admin.PUT("/workers", s.setWorkerCount)
+	admin.PUT("/limits", s.setLimits)
+	admin.POST("/pause", s.poolAction((*dispatcher.Pool).Pause))
+	admin.POST("/resume", s.poolAction((*dispatcher.Pool).Resume))
+	admin.POST("/drain", s.poolAction((*dispatcher.Pool).Drain))
+}
+
+// idParam reads a numeric path parameter, writing a 400 response if it is not valid
+func idParam(c *gin.Context, name string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"simple-go-app/internal/logging"
+	"simple-go-app/internal/parsing"
+	"simple-go-app/internal/store"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// figureResponse is a figure or table with its cells and coordinates decoded
+type figureResponse struct {
+	store.Figure
+	Rows   [][]string    `json:"rows"`
+	Coords []parsing.Box `json:"coords"`
+}
+
+// listPaperFigures returns the figures and tables of a paper in order
+func (s *Server) listPaperFigures(c *gin.Context) {
+	paperID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if _, err := s.Store.FindPaperByID(paperID); errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "paper not found"})
+		return
+	} else if err != nil {
+		logging.ErrorLogger.Println("Error finding paper:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not find paper"})
+		return
+	}
+
+	figures, err := s.Store.FindFiguresByPaper(paperID)
+	if err != nil {
+		logging.ErrorLogger.Println("Error finding figures:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not find figures"})
+		return
+	}
+
+	response := []figureResponse{}
+	for _, figure := range figures {
+		response = append(response, figureResponse{Figure: figure, Rows: figure.CellRows(), Coords: figure.BoxList()})
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// getTableCSV exports the cells of a table as CSV, so reviewers do not have to retype them
+func (s *Server) getTableCSV(c *gin.Context) {
+	paperID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	figureID, ok := idParam(c, "figure_id")
+	if !ok {
+		return
+	}
+
+	figure, err := s.Store.FindFigure(paperID, figureID)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "figure not found"})
+		return
+	}
+	if err != nil {
+		logging.ErrorLogger.Println("Error finding figure:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not find figure"})
+		return
+	}
+	if figure.Type != parsing.FigureTypeTable {
+		c.JSON(http.StatusNotFound, gin.H{"error": "figure is not a table"})
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="paper-%d-table-%d.csv"`, paperID, figureID))
+	c.Status(http.StatusOK)
+	writer := csv.NewWriter(c.Writer)
+	if err := writer.WriteAll(escapeFormulas(figure.CellRows())); err != nil {
+		logging.ErrorLogger.Println("Error writing table CSV:", err)
+	}
+}
+
+// escapeFormulas prefixes cells a spreadsheet would run as a formula with a quote, as cells come from the uploaded PDF
+func escapeFormulas(rows [][]string) [][]string {
+	escaped := make([][]string, len(rows))
+	for i, row := range rows {
+		escaped[i] = make([]string, len(row))
+		for j, cell := range row {
+			if cell != "" && strings.ContainsRune("=+-@", rune(cell[0])) {
+				cell = "'" + cell
+			}
+			escaped[i][j] = cell
+		}
+	}
+	return escaped
+}
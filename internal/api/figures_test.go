@@ -0,0 +1,15 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+// test cells a spreadsheet would run as formulas are quoted and others left alone
+func TestEscapeFormulas(t *testing.T) {
+	rows := [][]string{{"=SUM(A1:A2)", "+1", "-0.5", "@cmd", ""}, {"Control", "30", "a=b"}}
+	want := [][]string{{"'=SUM(A1:A2)", "'+1", "'-0.5", "'@cmd", ""}, {"Control", "30", "a=b"}}
+	if escaped := escapeFormulas(rows); !reflect.DeepEqual(escaped, want) {
+		t.Errorf("Unexpected cells %v", escaped)
+	}
+}
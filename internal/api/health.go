@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// livenessTimeout is how long the pool may take to report its status before we consider it deadlocked
+const livenessTimeout = 2 * time.Second
+
+// getLivez answers whether the process should be restarted: it fails when the pool cannot report its
+// status or a worker has been stuck on one message for longer than StuckWorkerTimeout
+func (s *Server) getLivez(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), livenessTimeout)
+	defer cancel()
+	status, err := s.Pool.TryStatus(ctx)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "error", "error": "worker pool did not respond"})
+		return
+	}
+
+	if stuck := status.Stuck(s.StuckWorkerTimeout); len(stuck) > 0 {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "error", "error": "workers stuck on a message", "workers": stuck})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// getReadyz answers whether the instance should be sent work, checking every dependency
+func (s *Server) getReadyz(c *gin.Context) {
+	results, ready := s.Readiness.Run(c.Request.Context())
+
+	status := "ok"
+	code := http.StatusOK
+	if !ready {
+		status = "error"
+		code = http.StatusServiceUnavailable
+	}
+	c.JSON(code, gin.H{"status": status, "checks": results})
+}
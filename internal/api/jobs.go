@@ -0,0 +1,98 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"simple-go-app/internal/helpers"
+	"simple-go-app/internal/jobs"
+	"simple-go-app/internal/logging"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// progressKeepAlive is how often an unchanged progress stream is resent so proxies keep the connection open
+const progressKeepAlive = 15 * time.Second
+
+func (s *Server) getJob(c *gin.Context) {
+	job, ok := s.Tracker.Get(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+func (s *Server) getScreenProgress(c *gin.Context) {
+	screenID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	progress, err := s.screenProgress(screenID)
+	if err != nil {
+		logging.ErrorLogger.Println("Error reading screen progress:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not read screen progress"})
+		return
+	}
+	c.JSON(http.StatusOK, progress)
+}
+
+// streamScreenProgress pushes the screen progress as Server-Sent Events every time one of its jobs changes
+func (s *Server) streamScreenProgress(c *gin.Context) {
+	screenID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	updates, unsubscribe := s.Tracker.Subscribe(screenID)
+	defer unsubscribe()
+
+	ticker := time.NewTicker(progressKeepAlive)
+	defer ticker.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+
+	// send returns false once the client has gone or a write fails, which ends the stream
+	send := func() bool {
+		if c.Request.Context().Err() != nil {
+			return false
+		}
+		progress, err := s.screenProgress(screenID)
+		if err != nil {
+			logging.ErrorLogger.Println("Error reading screen progress:", err)
+			return writeEvent(c, "error", gin.H{"error": "could not read screen progress"})
+		}
+		return writeEvent(c, "progress", progress)
+	}
+
+	if !send() {
+		return
+	}
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-updates:
+			return send()
+		case <-ticker.C:
+			return send()
+		}
+	})
+}
+
+// writeEvent writes a Server-Sent Event, returning false when it could not be written
+func writeEvent(c *gin.Context, name string, data any) bool {
+	return sse.Event{Event: name, Data: data}.Render(c.Writer) == nil
+}
+
+func (s *Server) screenProgress(screenID int64) (jobs.Progress, error) {
+	remaining, err := s.Cache.GetCacheCount(helpers.ScreenProcessingKey(screenID))
+	if err != nil {
+		return jobs.Progress{}, err
+	}
+	return s.Tracker.Progress(screenID, remaining, s.Pool.WorkerCount()), nil
+}
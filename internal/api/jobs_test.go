@@ -0,0 +1,31 @@
+package api
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// failingWriter is a client that has gone away
+type failingWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+// test an event that cannot be written ends the stream
+func TestWriteEvent(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	if !writeEvent(c, "progress", gin.H{"queued": 1}) {
+		t.Errorf("Expected the event to be written")
+	}
+
+	c, _ = gin.CreateTestContext(failingWriter{httptest.NewRecorder()})
+	if writeEvent(c, "progress", gin.H{"queued": 1}) {
+		t.Errorf("Expected a failed write to stop the stream")
+	}
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"simple-go-app/internal/logging"
+	"simple-go-app/internal/store"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// logTailBatch is the most log entries read per poll
+const logTailBatch = 100
+
+// streamScreenLogs tails the log entries of a screen
+func (s *Server) streamScreenLogs(c *gin.Context) {
+	screenID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	s.tailLogs(c, store.LogFilter{ScreenID: screenID})
+}
+
+// streamUserLogs tails the log entries of a user across their screens
+func (s *Server) streamUserLogs(c *gin.Context) {
+	userID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	s.tailLogs(c, store.LogFilter{UserID: userID})
+}
+
+// tailLogs pushes log entries as Server-Sent Events as they are written. The logs table is polled rather than
+// watched in process so entries written by other instances and by the main app are included.
+// A reconnecting client resumes after its Last-Event-ID, otherwise since_id or the newest entry.
+func (s *Server) tailLogs(c *gin.Context, filter store.LogFilter) {
+	if levels := c.Query("level"); levels != "" {
+		filter.Levels = strings.Split(levels, ",")
+	}
+	filter.Stage = c.Query("stage")
+
+	after, err := s.tailStart(c)
+	if err != nil {
+		logging.ErrorLogger.Println("Error reading last log id:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not read logs"})
+		return
+	}
+
+	poll := time.NewTicker(s.LogPollInterval)
+	defer poll.Stop()
+	keepAlive := time.NewTicker(progressKeepAlive)
+	defer keepAlive.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-keepAlive.C:
+			io.WriteString(w, ": keep-alive\n\n")
+			return true
+		case <-poll.C:
+		}
+
+		entries, err := s.Store.FindLogsAfter(after, filter, logTailBatch)
+		if err != nil {
+			logging.ErrorLogger.Println("Error tailing logs:", err)
+			c.SSEvent("error", gin.H{"error": "could not read logs"})
+			return true
+		}
+		for _, entry := range entries {
+			c.Render(-1, sse.Event{Id: strconv.FormatInt(entry.ID, 10), Event: "log", Data: entry})
+			after = entry.ID
+		}
+		return true
+	})
+}
+
+// tailStart is the id after which log entries are sent
+func (s *Server) tailStart(c *gin.Context) (int64, error) {
+	for _, value := range []string{c.GetHeader("Last-Event-ID"), c.Query("since_id")} {
+		if id, err := strconv.ParseInt(value, 10, 64); err == nil && id >= 0 {
+			return id, nil
+		}
+	}
+	return s.Store.LastLogID()
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+)
+
+//go:generate go run github.com/deepmap/oapi-codegen/cmd/oapi-codegen@v1.13.4 -generate types,client -package client -o ../../client/client.gen.go openapi.yaml
+
+//go:embed openapi.yaml
+var specYAML []byte
+
+var (
+	// Spec is the OpenAPI document describing the API
+	Spec       *openapi3.T
+	specJSON   []byte
+	specRouter routers.Router
+)
+
+func init() {
+	var err error
+	Spec, err = openapi3.NewLoader().LoadFromData(specYAML)
+	if err != nil {
+		log.Fatal("Error loading openapi.yaml:", err)
+	}
+	if err = Spec.Validate(context.Background()); err != nil {
+		log.Fatal("Error validating openapi.yaml:", err)
+	}
+	if specJSON, err = json.Marshal(Spec); err != nil {
+		log.Fatal("Error encoding openapi.yaml:", err)
+	}
+	if specRouter, err = gorillamux.NewRouter(Spec); err != nil {
+		log.Fatal("Error routing openapi.yaml:", err)
+	}
+}
+
+func (s *Server) getOpenAPI(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", specJSON)
+}
+
+// validate rejects requests whose parameters or JSON body do not match the spec. Other bodies, such as PDF
+// uploads, are left for the handler so they are not read into memory twice.
+func validate(c *gin.Context) {
+	route, pathParams, err := specRouter.FindRoute(c.Request)
+	if err != nil {
+		c.Next()
+		return
+	}
+
+	input := &openapi3filter.RequestValidationInput{
+		Request:    c.Request,
+		PathParams: pathParams,
+		Route:      route,
+		Options: &openapi3filter.Options{
+			// Credentials are checked by auth.Require
+			AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		},
+	}
+	if contentType := c.ContentType(); contentType != "" && contentType != gin.MIMEJSON {
+		input.Options.ExcludeRequestBody = true
+	}
+
+	if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Next()
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"simple-go-app/internal/auth"
+	"simple-go-app/internal/jobs"
+	"simple-go-app/internal/parsing"
+	"simple-go-app/internal/store"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/gin-gonic/gin"
+)
+
+const testAPIKey = "test-key"
+
+// routes registered in main.go rather than by Server.Register
+var mainRoutes = []string{"GET /", "GET /health", "GET /metrics"}
+
+func newTestServer(t *testing.T) (*gin.Engine, *Server) {
+	gin.SetMode(gin.TestMode)
+
+	hash := sha256.Sum256([]byte(testAPIKey))
+	authenticator, err := auth.New("test:"+hex.EncodeToString(hash[:])+":read|enqueue|parse|admin", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{Tracker: jobs.NewTracker(time.Hour), Auth: authenticator}
+	r := gin.New()
+	s.Register(r)
+	return r, s
+}
+
+// test every route is described by the spec and every operation in the spec is served
+func TestRoutesMatchSpec(t *testing.T) {
+	r, _ := newTestServer(t)
+
+	param := regexp.MustCompile(`:(\w+)`)
+	served := make(map[string]bool)
+	for _, route := range r.Routes() {
+		served[route.Method+" "+param.ReplaceAllString(route.Path, "{$1}")] = true
+	}
+	for _, route := range mainRoutes {
+		served[route] = true
+	}
+
+	described := make(map[string]bool)
+	for path, item := range Spec.Paths {
+		for method := range item.Operations() {
+			described[method+" "+path] = true
+		}
+	}
+
+	for route := range served {
+		if !described[route] {
+			t.Errorf("%s is served but missing from openapi.yaml", route)
+		}
+	}
+	for route := range described {
+		if !served[route] {
+			t.Errorf("%s is in openapi.yaml but not served", route)
+		}
+	}
+}
+
+// test handler responses match the schemas in the spec
+func TestResponsesMatchSpec(t *testing.T) {
+	r, s := newTestServer(t)
+	s.Tracker.Enqueue("a", `{"s3Location": "uploads/a.pdf", "user_id": "3", "screen_id": "7"}`)
+	s.Tracker.Start("a")
+	s.Tracker.Succeed("a", 42, false)
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{http.MethodGet, "/jobs/a", "", http.StatusOK},
+		{http.MethodGet, "/jobs/missing", "", http.StatusNotFound},
+		{http.MethodGet, "/screens/0/progress", "", http.StatusBadRequest},
+		{http.MethodPost, "/jobs", `{"s3Location": "uploads/a.pdf", "user_id": 3}`, http.StatusBadRequest},
+		{http.MethodPost, "/jobs", `{"s3Location": "uploads/a.pdf", "user_id": 3, "screen_id": 7, "grobid_options": {"tei_coordinates": ["table"]}}`, http.StatusBadRequest},
+		{http.MethodPut, "/screens/7/grobid-options", `{"consolidate_citations": 4}`, http.StatusBadRequest},
+		{http.MethodGet, "/screens/0/grobid-options", "", http.StatusBadRequest},
+		{http.MethodPut, "/admin/workers", `{"count": -1}`, http.StatusBadRequest},
+		{http.MethodPost, "/screens/7/uploads", "", http.StatusBadRequest},
+		{http.MethodPost, "/papers/1/reprocess", `{"source": "html"}`, http.StatusBadRequest},
+		{http.MethodGet, "/screens/7/papers?per_page=1000", "", http.StatusBadRequest},
+		{http.MethodGet, "/papers/0/figures", "", http.StatusBadRequest},
+		{http.MethodGet, "/papers/0/references", "", http.StatusBadRequest},
+		{http.MethodGet, "/papers/1/figures/0/csv", "", http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.body))
+		req.Header.Set(auth.HeaderAPIKey, testAPIKey)
+		if test.body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.want {
+			t.Errorf("%s %s: expected %d, got %d: %s", test.method, test.path, test.want, w.Code, w.Body.String())
+			continue
+		}
+
+		route, pathParams, err := specRouter.FindRoute(req)
+		if err != nil {
+			t.Fatalf("%s %s: %v", test.method, test.path, err)
+		}
+		err = openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    &openapi3filter.Options{ExcludeRequestBody: true},
+			},
+			Status: w.Code,
+			Header: w.Header(),
+			Body:   io.NopCloser(w.Body),
+		})
+		if err != nil {
+			t.Errorf("%s %s: response does not match the spec: %v", test.method, test.path, err)
+		}
+	}
+}
+
+// test the types returned to clients match their schemas, including when optional fields are empty
+func TestSchemasMatchTypes(t *testing.T) {
+	doi := "10.1000/xyz"
+	keywords := `["grobid", "tei"]`
+	surname := "Silva"
+	referenceID := int64(1)
+	journal := "Chemosphere"
+	issue := "3-4"
+	publishedDate, precision := "2020-07-01", "month"
+	label := "Background"
+	pubMedID := 12345
+	arXivID := "2101.00001"
+	consolidation := parsing.ConsolidateDOIOnly
+	coordinates := []string{"s", "figure"}
+	tests := []struct {
+		schema string
+		value  any
+	}{
+		{"PDFDTO", parsing.CreatePDFDTO(&parsing.TidyGrobidResponse{}, nil)},
+		{"PDFDTO", parsing.CreatePDFDTO(&parsing.TidyGrobidResponse{
+			Title:         "A paper",
+			Keywords:      []string{"grobid"},
+			Sections:      []parsing.Section{{Head: "Introduction", Number: "1", Depth: 1, Parent: -1, Paragraphs: []parsing.ParagraphRaw{{Text: "Text", Citations: []parsing.Citation{{Offset: 4, Marker: "[1]", Target: "b0"}}, Coords: []parsing.Box{{Page: 1, X: 53.8, Y: 96.2, Width: 240.1, Height: 8.9}}}}}},
+			Authors:       []parsing.Author{{Forename: "Ana", Surname: "Silva", Corresponding: true, Affiliations: []parsing.Affiliation{{Institution: "Universidade"}}}},
+			References:    []parsing.Reference{{TEIID: "b0", Title: "A cited paper", Authors: []string{"A Author"}, Year: "2020"}},
+			Figures:       []parsing.Figure{{TEIID: "tab_0", Type: parsing.FigureTypeTable, Label: "Table 1", Rows: [][]string{{"Dose", "n"}}}},
+			AbstractParts: []parsing.AbstractPart{{Label: "Background", Text: "Zebrafish."}},
+			Identifiers:   parsing.Identifiers{DOI: "10.1000/paper", PMID: "12345"},
+			PublishedDate: &parsing.PublishedDate{Year: 2020, Month: 7, Precision: parsing.DatePrecisionMonth},
+		}, nil)},
+		{"Paper", newPaperResponse(store.Paper{ID: 1, Slug: "a-paper", DOI: &doi, Title: "A paper"})},
+		{"Paper", newPaperResponse(store.Paper{ID: 1, Slug: "a-paper", Title: "A paper", Keywords: &keywords})},
+		{"Paper", newPaperResponse(store.Paper{ID: 1, Slug: "a-paper", Title: "A paper", Journal: &journal, Issue: &issue})},
+		{"Paper", newPaperResponse(store.Paper{ID: 1, Slug: "a-paper", Title: "A paper", PublishedDate: &publishedDate, PublishedDatePrecision: &precision})},
+		{"Paper", newPaperResponse(store.Paper{ID: 1, Slug: "a-paper", Title: "A paper", PubMedID: &pubMedID, ArXivID: &arXivID})},
+		{"PaperDetail", paperDetail{
+			paperResponse: newPaperResponse(store.Paper{ID: 1, Slug: "a-paper", Title: "A paper"}),
+			AbstractParts: []store.AbstractPart{{ID: 1, PaperID: 1, Label: &label, Text: "Zebrafish."}},
+			Authors:       []authorResponse{{PaperAuthor: store.PaperAuthor{AuthorID: 1, Surname: &surname}, Affiliations: []parsing.Affiliation{}}},
+			Sections:      []sectionResponse{{Section: store.Section{ID: 1, PaperID: 1, Header: "Introduction", Text: "Text"}, Coords: []parsing.Box{}}},
+		}},
+		{"Figure", figureResponse{Figure: store.Figure{ID: 1, PaperID: 1, Type: parsing.FigureTypeFigure}, Rows: [][]string{}, Coords: []parsing.Box{}}},
+		{"Reference", referenceResponse{Reference: store.Reference{ID: 1, PaperID: 1}, Authors: []string{}, Coords: []parsing.Box{}, Citations: []store.Citation{{ID: 1, SectionID: 2, ReferenceID: &referenceID, Offset: 4}}}},
+		{"PaperPage", papersPage{Data: []paperResponse{}, CurrentPage: 1, PerPage: 50, LastPage: 1}},
+		{"Progress", jobs.Progress{ScreenID: 7}},
+		{"GrobidOptions", parsing.GrobidOptions{}},
+		{"GrobidOptions", parsing.GrobidOptions{ConsolidateCitations: &consolidation, TEICoordinates: &coordinates}},
+		{"LogEntry", store.Log{ID: 1, Level: "info", UserMessage: "Paper has already been added", Stage: "pdf_processing"}},
+	}
+
+	for _, test := range tests {
+		encoded, err := json.Marshal(test.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded any
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if err := Spec.Components.Schemas[test.schema].Value.VisitJSON(decoded); err != nil {
+			t.Errorf("%s does not match its schema: %v", test.schema, err)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"simple-go-app/internal/logging"
+	"simple-go-app/internal/parsing"
+	"simple-go-app/internal/store"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPerPage = 50
+	maxPerPage     = 200
+)
+
+// paperResponse is a paper with its keywords decoded
+type paperResponse struct {
+	store.Paper
+	Keywords []string `json:"keywords"`
+}
+
+// paperDetail is a paper with its abstract parts, authors and sections in order
+type paperDetail struct {
+	paperResponse
+	AbstractParts []store.AbstractPart `json:"abstract_parts"`
+	Authors       []authorResponse     `json:"authors"`
+	Sections      []sectionResponse    `json:"sections"`
+}
+
+// sectionResponse is a section with its coordinates decoded
+type sectionResponse struct {
+	store.Section
+	Coords []parsing.Box `json:"coords"`
+}
+
+// authorResponse is an author of a paper with its affiliations decoded
+type authorResponse struct {
+	store.PaperAuthor
+	Affiliations []parsing.Affiliation `json:"affiliations"`
+}
+
+// papersPage follows the shape of the main app's paginated responses
+type papersPage struct {
+	Data        []paperResponse `json:"data"`
+	CurrentPage int             `json:"current_page"`
+	PerPage     int             `json:"per_page"`
+	LastPage    int             `json:"last_page"`
+	Total       int64           `json:"total"`
+}
+
+func newPaperResponse(paper store.Paper) paperResponse {
+	return paperResponse{Paper: paper, Keywords: paper.KeywordList()}
+}
+
+// listScreenPapers returns a page of a screen's papers, optionally filtered by doi, title or year
+func (s *Server) listScreenPapers(c *gin.Context) {
+	screenID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page"})
+		return
+	}
+	perPage, err := strconv.Atoi(c.DefaultQuery("per_page", strconv.Itoa(defaultPerPage)))
+	if err != nil || perPage < 1 || perPage > maxPerPage {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid per_page"})
+		return
+	}
+
+	filter := store.PaperFilter{DOI: c.Query("doi"), Title: c.Query("title"), Year: c.Query("year")}
+	papers, total, err := s.Store.ListPapers(screenID, filter, perPage, (page-1)*perPage)
+	if err != nil {
+		logging.ErrorLogger.Println("Error listing papers:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not list papers"})
+		return
+	}
+
+	response := papersPage{
+		Data:        make([]paperResponse, 0, len(papers)),
+		CurrentPage: page,
+		PerPage:     perPage,
+		LastPage:    int((total + int64(perPage) - 1) / int64(perPage)),
+		Total:       total,
+	}
+	if response.LastPage < 1 {
+		response.LastPage = 1
+	}
+	for _, paper := range papers {
+		response.Data = append(response.Data, newPaperResponse(paper))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// getPaper returns a paper with its keywords, authors and sections
+func (s *Server) getPaper(c *gin.Context) {
+	paperID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	paper, err := s.Store.FindPaperByID(paperID)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "paper not found"})
+		return
+	}
+	if err != nil {
+		logging.ErrorLogger.Println("Error finding paper:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not find paper"})
+		return
+	}
+
+	sections, err := s.Store.FindSectionsByPaper(paper.ID)
+	if err != nil {
+		logging.ErrorLogger.Println("Error finding sections:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not find sections"})
+		return
+	}
+
+	abstractParts, err := s.Store.FindAbstractPartsByPaper(paper.ID)
+	if err != nil {
+		logging.ErrorLogger.Println("Error finding abstract parts:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not find abstract parts"})
+		return
+	}
+
+	paperAuthors, err := s.Store.FindAuthorsByPaper(paper.ID)
+	if err != nil {
+		logging.ErrorLogger.Println("Error finding authors:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not find authors"})
+		return
+	}
+	authors := []authorResponse{}
+	for _, author := range paperAuthors {
+		authors = append(authors, authorResponse{PaperAuthor: author, Affiliations: author.AffiliationList()})
+	}
+
+	sectionResponses := []sectionResponse{}
+	for _, section := range sections {
+		sectionResponses = append(sectionResponses, sectionResponse{Section: section, Coords: section.BoxList()})
+	}
+
+	c.JSON(http.StatusOK, paperDetail{paperResponse: newPaperResponse(paper), AbstractParts: abstractParts, Authors: authors, Sections: sectionResponses})
+}
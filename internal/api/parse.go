@@ -0,0 +1,92 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"simple-go-app/internal/auth"
+	"simple-go-app/internal/logging"
+	"simple-go-app/internal/parsing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type enqueueRequest struct {
+	S3Location string `json:"s3Location" binding:"required"`
+	UserID     int64  `json:"user_id" binding:"required,gt=0"`
+	ScreenID   int64  `json:"screen_id" binding:"required,gt=0"`
+	// GrobidOptions override the screen's options for this PDF
+	GrobidOptions *parsing.GrobidOptions `json:"grobid_options"`
+}
+
+// parsePDF runs an uploaded PDF through Grobid and Crossref and returns the result without saving anything. Grobid
+// options may be sent as form fields next to the PDF.
+func (s *Server) parsePDF(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, s.MaxUploadBytes)
+	file, _, err := c.Request.FormFile("input")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing PDF in the input field: " + err.Error()})
+		return
+	}
+	defer file.Close()
+
+	requested, err := parsing.ParseGrobidOptions(c.Request.MultipartForm.Value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	opts, err := parsing.ResolveOptions(parsing.GrobidOptions{}, requested)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fileContent, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// a signed upload is only known to match its signature once the whole body has been read
+	if err := auth.VerifyBody(c.Request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	crudeGrobidResponse, err := parsing.SendPDF2Grobid(c.Request.Context(), s.Grobid, parsing.BytesPDF(fileContent), opts)
+	if err != nil {
+		logging.ErrorLogger.Println("Error sending file to Grobid service:", err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	tidyGrobidResponse, err := parsing.TidyUpGrobidResponse(crudeGrobidResponse)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	crossRefResponse := parsing.CrossReference(tidyGrobidResponse)
+	c.JSON(http.StatusOK, parsing.CreatePDFDTO(tidyGrobidResponse, crossRefResponse))
+}
+
+// enqueueJob queues a PDF that is already in the bucket, the same way the main app does after an upload
+func (s *Server) enqueueJob(c *gin.Context) {
+	var request enqueueRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if request.GrobidOptions != nil {
+		if err := request.GrobidOptions.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	job, err := s.Pool.Enqueue(request.S3Location, request.UserID, request.ScreenID, request.GrobidOptions)
+	if err != nil {
+		logging.ErrorLogger.Println("Error enqueueing job:", err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "could not enqueue job"})
+		return
+	}
+	c.JSON(http.StatusAccepted, job)
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"simple-go-app/internal/logging"
+	"simple-go-app/internal/parsing"
+	"simple-go-app/internal/store"
+
+	"github.com/gin-gonic/gin"
+)
+
+// referenceResponse is a bibliography entry with its authors and coordinates decoded and the citations of it in the paper
+type referenceResponse struct {
+	store.Reference
+	Authors   []string         `json:"authors"`
+	Coords    []parsing.Box    `json:"coords"`
+	Citations []store.Citation `json:"citations"`
+}
+
+// listPaperReferences returns the bibliography of a paper in order, each entry with the sections that cite it
+func (s *Server) listPaperReferences(c *gin.Context) {
+	paperID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if _, err := s.Store.FindPaperByID(paperID); errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "paper not found"})
+		return
+	} else if err != nil {
+		logging.ErrorLogger.Println("Error finding paper:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not find paper"})
+		return
+	}
+
+	references, err := s.Store.FindReferencesByPaper(paperID)
+	if err != nil {
+		logging.ErrorLogger.Println("Error finding references:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not find references"})
+		return
+	}
+	citations, err := s.Store.FindCitationsByPaper(paperID)
+	if err != nil {
+		logging.ErrorLogger.Println("Error finding citations:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not find citations"})
+		return
+	}
+
+	byReference := make(map[int64][]store.Citation)
+	for _, citation := range citations {
+		if citation.ReferenceID != nil {
+			byReference[*citation.ReferenceID] = append(byReference[*citation.ReferenceID], citation)
+		}
+	}
+	response := []referenceResponse{}
+	for _, reference := range references {
+		cited := byReference[reference.ID]
+		if cited == nil {
+			cited = []store.Citation{}
+		}
+		response = append(response, referenceResponse{Reference: reference, Authors: reference.AuthorList(), Coords: reference.BoxList(), Citations: cited})
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"simple-go-app/internal/dispatcher"
+	"simple-go-app/internal/jobs"
+	"simple-go-app/internal/logging"
+	"simple-go-app/internal/store"
+
+	"github.com/gin-gonic/gin"
+)
+
+type reprocessRequest struct {
+	Source string `json:"source"`
+}
+
+type reprocessResponse struct {
+	Jobs  []jobs.Job `json:"jobs"`
+	Error string     `json:"error,omitempty"`
+}
+
+// reprocessPaper queues a paper to have its sections extracted again
+func (s *Server) reprocessPaper(c *gin.Context) {
+	paperID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	source, ok := reprocessSource(c)
+	if !ok {
+		return
+	}
+
+	paper, err := s.Store.FindPaperByID(paperID)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "paper not found"})
+		return
+	}
+	if err != nil {
+		logging.ErrorLogger.Println("Error finding paper:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not find paper"})
+		return
+	}
+
+	s.enqueueReprocess(c, []store.Paper{paper}, source)
+}
+
+// reprocessScreen queues every paper of a screen to have its sections extracted again
+func (s *Server) reprocessScreen(c *gin.Context) {
+	screenID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	source, ok := reprocessSource(c)
+	if !ok {
+		return
+	}
+
+	papers, err := s.Store.FindPapersByScreen(screenID)
+	if err != nil {
+		logging.ErrorLogger.Println("Error finding papers:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not find papers"})
+		return
+	}
+
+	s.enqueueReprocess(c, papers, source)
+}
+
+func (s *Server) enqueueReprocess(c *gin.Context, papers []store.Paper, source string) {
+	response := reprocessResponse{Jobs: []jobs.Job{}}
+	for _, paper := range papers {
+		job, err := s.Pool.EnqueueReprocess(paper, source)
+		if err != nil {
+			logging.ErrorLogger.Println("Error enqueueing reprocess:", err)
+			// the papers queued before the failure are still listed
+			response.Error = err.Error()
+			c.JSON(http.StatusBadGateway, response)
+			return
+		}
+		response.Jobs = append(response.Jobs, job)
+	}
+	c.JSON(http.StatusAccepted, response)
+}
+
+// reprocessSource reads the optional body, defaulting to rerunning Grobid on the retained PDF
+func reprocessSource(c *gin.Context) (string, bool) {
+	request := reprocessRequest{Source: dispatcher.SourcePDF}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return "", false
+		}
+	}
+	if request.Source != dispatcher.SourcePDF && request.Source != dispatcher.SourceTEI {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "source must be pdf or tei"})
+		return "", false
+	}
+	return request.Source, true
+}
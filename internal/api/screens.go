@@ -0,0 +1,71 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"simple-go-app/internal/logging"
+	"simple-go-app/internal/parsing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getScreenGrobidOptions returns the Grobid options a screen's PDFs are processed with, its own over the defaults
+func (s *Server) getScreenGrobidOptions(c *gin.Context) {
+	screenID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	opts, err := s.Store.FindScreenGrobidOptions(screenID)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "screen not found"})
+		return
+	}
+	if err != nil {
+		logging.ErrorLogger.Println("Error finding screen Grobid options:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not find screen Grobid options"})
+		return
+	}
+	effectiveScreenOptions(c, opts)
+}
+
+// setScreenGrobidOptions replaces the Grobid options of a screen, an empty object restoring the defaults
+func (s *Server) setScreenGrobidOptions(c *gin.Context) {
+	screenID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	var opts parsing.GrobidOptions
+	if err := c.ShouldBindJSON(&opts); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := opts.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := s.Store.SaveScreenGrobidOptions(screenID, opts)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "screen not found"})
+		return
+	}
+	if err != nil {
+		logging.ErrorLogger.Println("Error saving screen Grobid options:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not save screen Grobid options"})
+		return
+	}
+	effectiveScreenOptions(c, opts)
+}
+
+// effectiveScreenOptions responds with the options a screen's PDFs are processed with, the screen's over the defaults
+func effectiveScreenOptions(c *gin.Context, screen parsing.GrobidOptions) {
+	opts, err := parsing.ResolveOptions(screen, parsing.GrobidOptions{})
+	if err != nil {
+		logging.ErrorLogger.Println("Error resolving screen Grobid options:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not resolve screen Grobid options"})
+		return
+	}
+	c.JSON(http.StatusOK, parsing.OptionsOf(opts))
+}
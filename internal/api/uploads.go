@@ -0,0 +1,99 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"simple-go-app/internal/auth"
+	"simple-go-app/internal/logging"
+	"simple-go-app/internal/uploads"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/gin-gonic/gin"
+)
+
+type uploadedJob struct {
+	Name       string `json:"name"`
+	S3Location string `json:"s3_location"`
+	JobID      string `json:"job_id"`
+}
+
+// uploadManifest lists what became of each entry of an archive
+type uploadManifest struct {
+	ScreenID int64               `json:"screen_id"`
+	Jobs     []uploadedJob       `json:"jobs"`
+	Rejected []uploads.Rejection `json:"rejected"`
+	Error    string              `json:"error,omitempty"`
+}
+
+// uploadArchive stores each PDF of a ZIP archive in the bucket and queues a job for it
+func (s *Server) uploadArchive(c *gin.Context) {
+	screenID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	userID, err := strconv.ParseInt(c.Query("user_id"), 10, 64)
+	if err != nil || userID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user_id"})
+		return
+	}
+
+	archive, size, err := uploads.Spool(c.Request.Body, s.UploadLimits)
+	if errors.Is(err, uploads.ErrArchiveTooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+	// a signed archive is only known to match its signature once the whole body has been read
+	if err := auth.VerifyBody(c.Request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	manifest := uploadManifest{ScreenID: screenID, Jobs: []uploadedJob{}, Rejected: []uploads.Rejection{}}
+	rejected, err := uploads.Extract(archive, size, s.UploadLimits, func(pdf uploads.PDF) error {
+		key := fmt.Sprintf("%s%d/%d-%s.pdf", s.UploadPrefix, screenID, time.Now().UnixNano(), pdf.SHA256)
+		_, err := s.S3.PutObjectWithContext(c.Request.Context(), &s3.PutObjectInput{
+			Bucket:      aws.String(s.S3Bucket),
+			Key:         aws.String(key),
+			Body:        bytes.NewReader(pdf.Content),
+			ContentType: aws.String("application/pdf"),
+		})
+		if err != nil {
+			return fmt.Errorf("storing %s: %w", pdf.Name, err)
+		}
+
+		job, err := s.Pool.Enqueue(key, userID, screenID, nil)
+		if err != nil {
+			return fmt.Errorf("enqueueing %s: %w", pdf.Name, err)
+		}
+		manifest.Jobs = append(manifest.Jobs, uploadedJob{Name: pdf.Name, S3Location: key, JobID: job.ID})
+		return nil
+	})
+	manifest.Rejected = append(manifest.Rejected, rejected...)
+
+	switch {
+	case errors.Is(err, uploads.ErrTooManyEntries):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, zip.ErrFormat):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case err != nil:
+		// the jobs queued before the failure are still listed so they are not uploaded twice
+		logging.ErrorLogger.Println("Error uploading archive:", err)
+		manifest.Error = err.Error()
+		c.JSON(http.StatusBadGateway, manifest)
+	default:
+		c.JSON(http.StatusAccepted, manifest)
+	}
+}
@@ -0,0 +1,269 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"simple-go-app/internal/helpers"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// Kinds of archived files, also their key suffixes
+const (
+	KindTEI = ".tei.xml"
+	KindPDF = ".pdf"
+)
+
+// ErrNotFound is returned by Blobs.Get for a key that was never written
+var ErrNotFound = errors.New("blob not found")
+
+// Blobs is where archived files are written
+type Blobs interface {
+	Put(key string, body io.Reader, contentType string) error
+	Get(key string) ([]byte, error)
+	// List returns the keys under prefix
+	List(prefix string) ([]string, error)
+}
+
+// Archive keeps the TEI Grobid returned for each paper, and optionally the PDF, so the parser can be replayed over
+// every paper without Grobid. Files are stored as <prefix><paper id>/<sha256 of the file><kind>: the same content
+// is only stored once per paper, and a new Grobid run adds a TEI next to the previous ones.
+type Archive struct {
+	blobs      Blobs
+	prefix     string
+	includePDF bool
+}
+
+// Entry is an archived file
+type Entry struct {
+	PaperID int64
+	SHA256  string
+	Kind    string
+	Key     string
+}
+
+// New creates an Archive writing under prefix, keeping PDFs too when includePDF is set
+func New(blobs Blobs, prefix string, includePDF bool) *Archive {
+	return &Archive{blobs: blobs, prefix: prefix, includePDF: includePDF}
+}
+
+// NewFromEnv reads ARCHIVE_BACKEND, "s3" for a bucket (ARCHIVE_BUCKET, the uploads bucket by default) or "dir" for
+// a local directory (ARCHIVE_DIR), and returns nil when it is unset, which turns archiving off
+func NewFromEnv(s3Svc *s3.S3, defaultBucket string) (*Archive, error) {
+	var blobs Blobs
+	switch backend := helpers.GetEnvVariableDefault("ARCHIVE_BACKEND", ""); backend {
+	case "":
+		return nil, nil
+	case "s3":
+		blobs = S3Blobs{Svc: s3Svc, Bucket: helpers.GetEnvVariableDefault("ARCHIVE_BUCKET", defaultBucket)}
+	case "dir":
+		blobs = DirBlobs{Dir: helpers.GetEnvVariable("ARCHIVE_DIR")}
+	default:
+		return nil, fmt.Errorf("unknown ARCHIVE_BACKEND %q", backend)
+	}
+	return New(blobs, helpers.GetEnvVariableDefault("ARCHIVE_PREFIX", "archive/"), helpers.GetEnvVariableDefault("ARCHIVE_PDF", "false") == "true"), nil
+}
+
+// Save archives the TEI of a paper, and its PDF when PDFs are kept. A nil pdf is skipped, such as when the TEI came
+// from a reparse. pdfSHA256 is the hex SHA-256 of the PDF when it is already known; otherwise the PDF is opened twice,
+// to hash it and then to store it, so it is never held in memory.
+func (a *Archive) Save(ctx context.Context, paperID int64, pdf func(ctx context.Context) (io.ReadCloser, error), pdfSHA256 string, tei []byte) error {
+	if a.includePDF && pdf != nil {
+		if err := a.savePDF(ctx, paperID, pdf, pdfSHA256); err != nil {
+			return err
+		}
+	}
+	sum := sha256.Sum256(tei)
+	return a.blobs.Put(a.key(paperID, hex.EncodeToString(sum[:]), KindTEI), bytes.NewReader(tei), "application/xml")
+}
+
+func (a *Archive) savePDF(ctx context.Context, paperID int64, open func(ctx context.Context) (io.ReadCloser, error), sha string) error {
+	if sha == "" {
+		pdf, err := open(ctx)
+		if err != nil {
+			return err
+		}
+		hash := sha256.New()
+		_, err = io.Copy(hash, pdf)
+		pdf.Close()
+		if err != nil {
+			return err
+		}
+		sha = hex.EncodeToString(hash.Sum(nil))
+	}
+
+	pdf, err := open(ctx)
+	if err != nil {
+		return err
+	}
+	defer pdf.Close()
+	return a.blobs.Put(a.key(paperID, sha, KindPDF), pdf, "application/pdf")
+}
+
+func (a *Archive) key(paperID int64, sha string, kind string) string {
+	return fmt.Sprintf("%s%d/%s%s", a.prefix, paperID, sha, kind)
+}
+
+// Entries lists the archived files of every paper, ordered by paper id then key, skipping keys it does not recognise
+func (a *Archive) Entries() ([]Entry, error) {
+	keys, err := a.blobs.List(a.prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, key := range keys {
+		if entry, ok := a.parseKey(key); ok {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].PaperID != entries[j].PaperID {
+			return entries[i].PaperID < entries[j].PaperID
+		}
+		return entries[i].Key < entries[j].Key
+	})
+	return entries, nil
+}
+
+// Read returns the content of an archived file
+func (a *Archive) Read(entry Entry) ([]byte, error) {
+	return a.blobs.Get(entry.Key)
+}
+
+func (a *Archive) parseKey(key string) (Entry, bool) {
+	paperDir, name, found := strings.Cut(strings.TrimPrefix(key, a.prefix), "/")
+	if !found {
+		return Entry{}, false
+	}
+	paperID, err := strconv.ParseInt(paperDir, 10, 64)
+	if err != nil {
+		return Entry{}, false
+	}
+	for _, kind := range []string{KindTEI, KindPDF} {
+		if sha, ok := strings.CutSuffix(name, kind); ok && len(sha) == sha256.Size*2 {
+			return Entry{PaperID: paperID, SHA256: sha, Kind: kind, Key: key}, true
+		}
+	}
+	return Entry{}, false
+}
+
+// S3Blobs stores archived files in an S3 bucket
+type S3Blobs struct {
+	Svc    *s3.S3
+	Bucket string
+}
+
+// Put uploads in parts, so a large body is streamed rather than read into memory
+func (b S3Blobs) Put(key string, body io.Reader, contentType string) error {
+	_, err := s3manager.NewUploaderWithClient(b.Svc).Upload(&s3manager.UploadInput{
+		Bucket:      aws.String(b.Bucket),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	})
+	return err
+}
+
+func (b S3Blobs) Get(key string) ([]byte, error) {
+	output, err := b.Svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(b.Bucket),
+		Key:    aws.String(key),
+	})
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer output.Body.Close()
+	return io.ReadAll(output.Body)
+}
+
+func (b S3Blobs) List(prefix string) ([]string, error) {
+	var keys []string
+	err := b.Svc.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(b.Bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			keys = append(keys, aws.StringValue(object.Key))
+		}
+		return true
+	})
+	return keys, err
+}
+
+// DirBlobs stores archived files in a local directory, keys being paths relative to it
+type DirBlobs struct {
+	Dir string
+}
+
+func (b DirBlobs) Put(key string, body io.Reader, contentType string) error {
+	name := filepath.Join(b.Dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	// written aside and renamed so a replay never reads a partial file
+	tmp := name + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, name)
+}
+
+func (b DirBlobs) Get(key string) ([]byte, error) {
+	body, err := os.ReadFile(filepath.Join(b.Dir, filepath.FromSlash(key)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return body, err
+}
+
+func (b DirBlobs) List(prefix string) ([]string, error) {
+	var keys []string
+	err := filepath.WalkDir(b.Dir, func(name string, entry os.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(b.Dir, name)
+		if err != nil {
+			return err
+		}
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) && path.Ext(key) != ".tmp" {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	return keys, err
+}
@@ -0,0 +1,104 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func pdf(content string) func(ctx context.Context) (io.ReadCloser, error) {
+	return func(ctx context.Context) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader([]byte(content))), nil
+	}
+}
+
+// test files are keyed by paper and content hash, PDFs only kept when asked, and listed back for a replay
+func TestArchive(t *testing.T) {
+	dir := t.TempDir()
+	a := New(DirBlobs{Dir: dir}, "archive/", false)
+
+	if err := a.Save(context.Background(), 2, pdf("%PDF-1.7 b"), "", []byte("<TEI>b</TEI>")); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Save(context.Background(), 1, pdf("%PDF-1.7 a"), "", []byte("<TEI>a</TEI>")); err != nil {
+		t.Fatal(err)
+	}
+	// the same TEI again is stored once, a new Grobid run is kept next to it
+	a.Save(context.Background(), 1, nil, "", []byte("<TEI>a</TEI>"))
+	a.Save(context.Background(), 1, nil, "", []byte("<TEI>a, again</TEI>"))
+	// files the archive did not write are skipped
+	os.WriteFile(filepath.Join(dir, "archive", "README"), []byte("notes"), 0o644)
+
+	entries, err := a.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %+v", entries)
+	}
+	if entries[0].PaperID != 1 || entries[1].PaperID != 1 || entries[2].PaperID != 2 {
+		t.Errorf("Unexpected order: %+v", entries)
+	}
+	for _, entry := range entries {
+		if entry.Kind != KindTEI {
+			t.Errorf("Unexpected kind of %s", entry.Key)
+		}
+	}
+
+	tei, err := a.Read(entries[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(tei) != "<TEI>b</TEI>" {
+		t.Errorf("Unexpected TEI: %s", tei)
+	}
+	if want := "archive/2/" + entries[2].SHA256 + ".tei.xml"; entries[2].Key != want {
+		t.Errorf("Unexpected key %s", entries[2].Key)
+	}
+}
+
+// test PDFs are archived next to the TEI when enabled
+func TestArchivePDF(t *testing.T) {
+	a := New(DirBlobs{Dir: t.TempDir()}, "", true)
+	if err := a.Save(context.Background(), 7, pdf("%PDF-1.7"), "", []byte("<TEI/>")); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := a.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	kinds := make(map[string]Entry)
+	for _, entry := range entries {
+		kinds[entry.Kind] = entry
+	}
+	if len(entries) != 2 || len(kinds) != 2 {
+		t.Fatalf("Unexpected entries: %+v", entries)
+	}
+	pdf, err := a.Read(kinds[KindPDF])
+	if err != nil || string(pdf) != "%PDF-1.7" {
+		t.Errorf("Unexpected PDF %q: %v", pdf, err)
+	}
+}
+
+// test a PDF whose hash is known is opened only to be stored
+func TestArchivePDF_KnownHash(t *testing.T) {
+	a := New(DirBlobs{Dir: t.TempDir()}, "", true)
+	opened := 0
+	open := func(ctx context.Context) (io.ReadCloser, error) {
+		opened++
+		return pdf("%PDF-1.7")(ctx)
+	}
+	if err := a.Save(context.Background(), 7, open, "abc123", []byte("<TEI/>")); err != nil {
+		t.Fatal(err)
+	}
+	if opened != 1 {
+		t.Errorf("Expected the PDF to be opened once, got %d", opened)
+	}
+	if _, err := a.Read(Entry{Key: "7/abc123.pdf"}); err != nil {
+		t.Errorf("Expected the PDF under its known hash: %v", err)
+	}
+}
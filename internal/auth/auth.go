@@ -0,0 +1,334 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Scope is a permission granted to a key
+type Scope string
+
+const (
+	ScopeParse   Scope = "parse"
+	ScopeEnqueue Scope = "enqueue"
+	ScopeAdmin   Scope = "admin"
+	ScopeRead    Scope = "read"
+)
+
+// Headers used to authenticate a request
+const (
+	HeaderAPIKey        = "X-API-Key"
+	HeaderKeyID         = "X-Key-Id"
+	HeaderTimestamp     = "X-Timestamp"
+	HeaderContentSHA256 = "X-Content-SHA256"
+	HeaderSignature     = "X-Signature"
+)
+
+// KeyIDContextKey is where the id of the authenticated key is stored on the gin context
+const KeyIDContextKey = "auth.key_id"
+
+// MaxClockSkew is how far the timestamp of a signed request may be from our clock
+const MaxClockSkew = 5 * time.Minute
+
+// MaxBufferedBody is the largest signed body Require reads to verify it, streamed uploads go through RequireStream
+const MaxBufferedBody = 1 << 20
+
+var (
+	errUnauthenticated = errors.New("missing or invalid credentials")
+	errBodyMismatch    = errors.New("request body does not match " + HeaderContentSHA256)
+	errReplayed        = errors.New("request signature has already been used")
+)
+
+// key is a configured credential; static keys only keep the SHA-256 of the key, signing keys keep the secret
+type key struct {
+	id     string
+	hash   []byte
+	secret []byte
+	scopes map[Scope]bool
+}
+
+// Authenticator checks requests carry a static API key or an HMAC signature with the required scope
+type Authenticator struct {
+	apiKeys  []key
+	hmacKeys map[string]key
+	public   map[string]bool
+	now      func() time.Time
+
+	// used holds the signatures seen within the clock skew window, by key id and signature, until they expire
+	mu   sync.Mutex
+	used map[string]time.Time
+}
+
+// New creates an Authenticator.
+//
+// apiKeys is a comma separated list of id:sha256hex:scope|scope entries, where sha256hex is the hex encoded
+// SHA-256 of the key clients send in the X-API-Key header or as a bearer token.
+// hmacKeys is a comma separated list of id:secret:scope|scope entries used to verify signed requests.
+// public is a comma separated list of route paths that do not need credentials.
+func New(apiKeys, hmacKeys, public string) (*Authenticator, error) {
+	a := &Authenticator{
+		hmacKeys: make(map[string]key),
+		public:   make(map[string]bool),
+		now:      time.Now,
+		used:     make(map[string]time.Time),
+	}
+
+	for _, entry := range splitList(apiKeys) {
+		k, err := parseKey(entry)
+		if err != nil {
+			return nil, fmt.Errorf("API_KEYS: %w", err)
+		}
+		k.hash, err = hex.DecodeString(string(k.secret))
+		if err != nil || len(k.hash) != sha256.Size {
+			return nil, fmt.Errorf("API_KEYS: key %s is not a hex encoded SHA-256 hash", k.id)
+		}
+		k.secret = nil
+		a.apiKeys = append(a.apiKeys, k)
+	}
+
+	for _, entry := range splitList(hmacKeys) {
+		k, err := parseKey(entry)
+		if err != nil {
+			return nil, fmt.Errorf("HMAC_KEYS: %w", err)
+		}
+		if _, ok := a.hmacKeys[k.id]; ok {
+			return nil, fmt.Errorf("HMAC_KEYS: duplicate key id %s", k.id)
+		}
+		a.hmacKeys[k.id] = k
+	}
+
+	for _, path := range splitList(public) {
+		a.public[path] = true
+	}
+
+	return a, nil
+}
+
+// HasKeys reports whether any credential is configured
+func (a *Authenticator) HasKeys() bool {
+	return len(a.apiKeys) > 0 || len(a.hmacKeys) > 0
+}
+
+// Require returns middleware that only lets through requests authenticated with a key holding scope,
+// unless the route has been configured as public. The body of a signed request is read and verified before the
+// handler runs, up to MaxBufferedBody.
+func (a *Authenticator) Require(scope Scope) gin.HandlerFunc {
+	return a.require(scope, false)
+}
+
+// RequireStream is Require for routes whose body is streamed, such as uploads. The body of a signed request is
+// only verified once it has been read to the end, so the handler calls VerifyBody after parsing it and before
+// acting on it.
+func (a *Authenticator) RequireStream(scope Scope) gin.HandlerFunc {
+	return a.require(scope, true)
+}
+
+// VerifyBody reads what is left of the body, failing when a signed body does not match its X-Content-SHA256
+func VerifyBody(r *http.Request) error {
+	if r.Body == nil {
+		return nil
+	}
+	_, err := io.Copy(io.Discard, r.Body)
+	return err
+}
+
+func (a *Authenticator) require(scope Scope, stream bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if a.public[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		k, err := a.authenticate(c.Request)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		if !k.scopes[scope] {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("key %s is missing the %s scope", k.id, scope)})
+			return
+		}
+
+		if verifier, ok := c.Request.Body.(*verifyingReader); ok && !stream {
+			body, err := io.ReadAll(io.LimitReader(verifier, MaxBufferedBody+1))
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			if len(body) > MaxBufferedBody {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "signed body is too large"})
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		c.Set(KeyIDContextKey, k.id)
+		c.Next()
+	}
+}
+
+// authenticate finds the key a request was made with
+func (a *Authenticator) authenticate(r *http.Request) (key, error) {
+	if r.Header.Get(HeaderSignature) != "" {
+		return a.verifySignature(r)
+	}
+
+	provided := r.Header.Get(HeaderAPIKey)
+	if provided == "" {
+		provided = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+	if provided == "" {
+		return key{}, errUnauthenticated
+	}
+
+	sum := sha256.Sum256([]byte(provided))
+	for _, k := range a.apiKeys {
+		if subtle.ConstantTimeCompare(sum[:], k.hash) == 1 {
+			return k, nil
+		}
+	}
+	return key{}, errUnauthenticated
+}
+
+// verifySignature checks the HMAC signature of a request, and that it has not been used before. The body is not
+// read here: it is wrapped so that reading it to the end fails if it does not hash to the signed X-Content-SHA256.
+func (a *Authenticator) verifySignature(r *http.Request) (key, error) {
+	k, ok := a.hmacKeys[r.Header.Get(HeaderKeyID)]
+	if !ok {
+		return key{}, errUnauthenticated
+	}
+
+	timestamp := r.Header.Get(HeaderTimestamp)
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return key{}, errUnauthenticated
+	}
+	skew := a.now().Sub(time.Unix(seconds, 0))
+	if skew > MaxClockSkew || skew < -MaxClockSkew {
+		return key{}, errors.New("request timestamp is too far from the server clock")
+	}
+
+	contentHash := strings.ToLower(r.Header.Get(HeaderContentSHA256))
+	expected := Sign(k.secret, r.Method, r.URL.RequestURI(), timestamp, contentHash)
+	provided, err := hex.DecodeString(r.Header.Get(HeaderSignature))
+	if err != nil || !hmac.Equal(provided, expected) {
+		return key{}, errUnauthenticated
+	}
+	if !a.firstUse(k.id, provided, time.Unix(seconds, 0).Add(MaxClockSkew)) {
+		return key{}, errReplayed
+	}
+
+	if r.Body != nil && r.Body != http.NoBody {
+		r.Body = &verifyingReader{body: r.Body, hash: sha256.New(), expected: contentHash}
+	} else if contentHash != EmptySHA256 {
+		return key{}, errBodyMismatch
+	}
+	return k, nil
+}
+
+// firstUse records a signature until it expires, reporting whether it had not been seen before. A signature is
+// rejected by the clock skew check once it expires, so it does not need to be kept longer.
+func (a *Authenticator) firstUse(keyID string, signature []byte, expires time.Time) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now()
+	for used, expiry := range a.used {
+		if expiry.Before(now) {
+			delete(a.used, used)
+		}
+	}
+	id := keyID + ":" + hex.EncodeToString(signature)
+	if _, ok := a.used[id]; ok {
+		return false
+	}
+	a.used[id] = expires
+	return true
+}
+
+// EmptySHA256 is the X-Content-SHA256 of a request without a body
+var EmptySHA256 = hex.EncodeToString(sha256.New().Sum(nil))
+
+// Sign computes the HMAC-SHA256 signature of a request, clients send it hex encoded in X-Signature.
+// contentHash is the hex encoded SHA-256 of the body, also sent in X-Content-SHA256.
+func Sign(secret []byte, method, requestURI, timestamp, contentHash string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strings.Join([]string{method, requestURI, timestamp, contentHash}, "\n")))
+	return mac.Sum(nil)
+}
+
+// verifyingReader hashes a body as it is read and fails at EOF if it does not match the signed hash
+type verifyingReader struct {
+	body     io.ReadCloser
+	hash     hash.Hash
+	expected string
+}
+
+func (v *verifyingReader) Read(p []byte) (int, error) {
+	n, err := v.body.Read(p)
+	v.hash.Write(p[:n])
+	if err == io.EOF && hex.EncodeToString(v.hash.Sum(nil)) != v.expected {
+		return n, errBodyMismatch
+	}
+	return n, err
+}
+
+func (v *verifyingReader) Close() error {
+	return v.body.Close()
+}
+
+// parseKey reads an id:value:scope|scope entry, keeping value in secret
+func parseKey(entry string) (key, error) {
+	parts := strings.SplitN(entry, ":", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return key{}, fmt.Errorf("invalid entry %q, expected id:value:scope|scope", redact(entry))
+	}
+
+	k := key{id: parts[0], secret: []byte(parts[1]), scopes: make(map[Scope]bool)}
+	for _, scope := range strings.Split(parts[2], "|") {
+		switch s := Scope(strings.TrimSpace(scope)); s {
+		case ScopeParse, ScopeEnqueue, ScopeAdmin, ScopeRead:
+			k.scopes[s] = true
+		default:
+			return key{}, fmt.Errorf("key %s has unknown scope %q", k.id, scope)
+		}
+	}
+	return k, nil
+}
+
+// redact hides the secret part of a key entry so it can be logged
+func redact(entry string) string {
+	if i := strings.Index(entry, ":"); i >= 0 {
+		return entry[:i] + ":..."
+	}
+	return "..."
+}
+
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// HashBody returns the X-Content-SHA256 value for a body
+func HashBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTestRouter(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	hash := sha256.Sum256([]byte("laravel-key"))
+	a, err := New("laravel:"+hex.EncodeToString(hash[:])+":read|enqueue", "scripts:s3cret:parse", "/health")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := gin.New()
+	r.GET("/health", a.Require(ScopeRead), func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/jobs/:id", a.Require(ScopeRead), func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.POST("/admin/pause", a.Require(ScopeAdmin), func(c *gin.Context) { c.Status(http.StatusOK) })
+	// the handler does not read the body, which Require has already verified
+	r.POST("/parse", a.Require(ScopeParse), func(c *gin.Context) { c.Status(http.StatusOK) })
+	// the handler stops reading early, as a multipart parser can, and verifies what is left
+	r.POST("/uploads", a.RequireStream(ScopeParse), func(c *gin.Context) {
+		c.Request.Body.Read(make([]byte, 1))
+		if err := VerifyBody(c.Request); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func signedRequest(path, body, signedBody string, at time.Time) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	contentHash := HashBody([]byte(signedBody))
+	req.Header.Set(HeaderKeyID, "scripts")
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderContentSHA256, contentHash)
+	req.Header.Set(HeaderSignature, hex.EncodeToString(Sign([]byte("s3cret"), http.MethodPost, path, timestamp, contentHash)))
+	return req
+}
+
+func TestAuthenticator_Require(t *testing.T) {
+	r := newTestRouter(t)
+
+	withKey := func(method, path, apiKey string) *http.Request {
+		req := httptest.NewRequest(method, path, nil)
+		if apiKey != "" {
+			req.Header.Set(HeaderAPIKey, apiKey)
+		}
+		return req
+	}
+
+	// each signed request gets its own timestamp, so only the replayed one repeats a signature
+	now := time.Now()
+	r.ServeHTTP(httptest.NewRecorder(), signedRequest("/parse", "pdf", "pdf", now.Add(-time.Minute)))
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		want int
+	}{
+		{"public endpoint", withKey(http.MethodGet, "/health", ""), http.StatusOK},
+		{"missing key", withKey(http.MethodGet, "/jobs/1", ""), http.StatusUnauthorized},
+		{"wrong key", withKey(http.MethodGet, "/jobs/1", "not-the-key"), http.StatusUnauthorized},
+		{"valid key", withKey(http.MethodGet, "/jobs/1", "laravel-key"), http.StatusOK},
+		{"missing scope", withKey(http.MethodPost, "/admin/pause", "laravel-key"), http.StatusForbidden},
+		{"signed request", signedRequest("/parse", "pdf", "pdf", now), http.StatusOK},
+		{"tampered body", signedRequest("/parse", "other pdf", "pdf", now.Add(-time.Second)), http.StatusBadRequest},
+		{"replayed signature", signedRequest("/parse", "pdf", "pdf", now.Add(-time.Minute)), http.StatusUnauthorized},
+		{"signed stream", signedRequest("/uploads", "zip", "zip", now), http.StatusOK},
+		{"tampered stream", signedRequest("/uploads", "other zip", "zip", now.Add(-time.Second)), http.StatusBadRequest},
+		{"stale signature", signedRequest("/parse", "pdf", "pdf", time.Now().Add(-time.Hour)), http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, test.req)
+		if w.Code != test.want {
+			t.Errorf("%s: expected %d, got %d", test.name, test.want, w.Code)
+		}
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	if _, err := New("laravel:not-a-hash:read", "", ""); err == nil {
+		t.Errorf("Expected an error for a key that is not a SHA-256 hash")
+	}
+	if _, err := New("", "scripts:s3cret:everything", ""); err == nil {
+		t.Errorf("Expected an error for an unknown scope")
+	}
+}
@@ -5,17 +5,26 @@ import (
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"log"
 	"simple-go-app/internal/helpers"
+	"simple-go-app/internal/metrics"
 	"strconv"
 )
 
-func Dispatcher(svc *sqs.SQS, sqsURL string, messageQueue chan<- *sqs.Message) {
+// dispatch receives messages from SQS and hands them to the workers while the pool is running
+func (p *Pool) dispatch() {
 	log.Println("Starting dispatcher...")
 	maxNumberOfMessagesInt64, _ := strconv.ParseInt(helpers.GetEnvVariable("DISPATCHER_MAX_MESSAGES"), 10, 64)
 	visibilityTimeoutInt64, _ := strconv.ParseInt(helpers.GetEnvVariable("DISPATCHER_VISIBILITY_TIMEOUT"), 10, 64)
 	waitTimeSecondsInt64, _ := strconv.ParseInt(helpers.GetEnvVariable("DISPATCHER_WAIT_TIME_SECONDS"), 10, 64)
 	for {
-		result, err := svc.ReceiveMessage(&sqs.ReceiveMessageInput{
-			QueueUrl:            aws.String(sqsURL),
+		state, changed := p.gate()
+		if state != StateRunning {
+			// paused or draining, wait until someone changes the state
+			<-changed
+			continue
+		}
+
+		result, err := p.sqsSvc.ReceiveMessage(&sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(p.sqsURL),
 			MaxNumberOfMessages: aws.Int64(maxNumberOfMessagesInt64),
 			VisibilityTimeout:   aws.Int64(visibilityTimeoutInt64),
 			WaitTimeSeconds:     aws.Int64(waitTimeSecondsInt64),
@@ -25,8 +34,33 @@ func Dispatcher(svc *sqs.SQS, sqsURL string, messageQueue chan<- *sqs.Message) {
 			continue
 		}
 
-		for _, message := range result.Messages {
-			messageQueue <- message
+		metrics.MessagesReceived.Add(float64(len(result.Messages)))
+	messages:
+		for i, message := range result.Messages {
+			p.tracker.Enqueue(*message.MessageId, *message.Body)
+			select {
+			case p.messages <- message:
+				metrics.BufferedMessages.Set(float64(len(p.messages)))
+			case <-changed:
+				// the pool stopped consuming while we waited on the workers, give the rest back to SQS
+				for _, remaining := range result.Messages[i:] {
+					p.release(remaining)
+				}
+				break messages
+			}
 		}
 	}
 }
+
+// release makes a message that has been received but not processed visible to other consumers again
+func (p *Pool) release(message *sqs.Message) {
+	_, err := p.sqsSvc.ChangeMessageVisibility(&sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          aws.String(p.sqsURL),
+		ReceiptHandle:     message.ReceiptHandle,
+		VisibilityTimeout: aws.Int64(0),
+	})
+	if err != nil {
+		log.Println("Error releasing message back to the queue:", err)
+	}
+	p.tracker.Release(*message.MessageId)
+}
@@ -0,0 +1,60 @@
+package dispatcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"simple-go-app/internal/helpers"
+	"simple-go-app/internal/jobs"
+	"simple-go-app/internal/parsing"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+)
+
+// Request is the body of a message on the requests queue, the ids are strings because that is how the main app sends them
+type Request struct {
+	S3Location string `json:"s3Location"`
+	UserID     string `json:"user_id"`
+	ScreenID   string `json:"screen_id"`
+	// PaperID is set when an existing paper is being reprocessed from Source
+	PaperID string `json:"paper_id,omitempty"`
+	Source  string `json:"source,omitempty"`
+	// GrobidOptions override the screen's options for this PDF
+	GrobidOptions *parsing.GrobidOptions `json:"grobid_options,omitempty"`
+}
+
+// Enqueue sends a request for a PDF already uploaded to the bucket, counting it towards the papers the screen has
+// processing. opts may be nil to use the screen's options.
+func (p *Pool) Enqueue(s3Location string, userID, screenID int64, opts *parsing.GrobidOptions) (jobs.Job, error) {
+	body, err := json.Marshal(Request{
+		S3Location:    s3Location,
+		UserID:        strconv.FormatInt(userID, 10),
+		ScreenID:      strconv.FormatInt(screenID, 10),
+		GrobidOptions: opts,
+	})
+	if err != nil {
+		return jobs.Job{}, err
+	}
+
+	key := helpers.ScreenProcessingKey(screenID)
+	if err := p.cacheSvc.AddOrIncrCache(key); err != nil {
+		return jobs.Job{}, err
+	}
+
+	result, err := p.sqsSvc.SendMessage(&sqs.SendMessageInput{
+		MessageBody: aws.String(string(body)),
+		QueueUrl:    aws.String(p.sqsURL),
+	})
+	if err != nil {
+		// the message never made it, so the screen should not wait for it
+		if decrErr := p.cacheSvc.DecrOrDeleteCache(key); decrErr != nil {
+			return jobs.Job{}, fmt.Errorf("sending message: %w, then decrementing %s: %w", err, key, decrErr)
+		}
+		return jobs.Job{}, err
+	}
+
+	p.tracker.Enqueue(*result.MessageId, string(body))
+	job, _ := p.tracker.Get(*result.MessageId)
+	return job, nil
+}
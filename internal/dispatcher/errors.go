@@ -0,0 +1,67 @@
+package dispatcher
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net"
+	"simple-go-app/internal/parsing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/go-sql-driver/mysql"
+)
+
+// errS3 marks failures reading from or writing to the uploads bucket
+var errS3 = errors.New("s3")
+
+// Classes of error a failed message is counted under in metrics.MessagesProcessed
+const (
+	ErrorClassTimeout        = "timeout"
+	ErrorClassTooLarge       = "too_large"
+	ErrorClassInvalidOptions = "invalid_options"
+	ErrorClassInvalidMessage = "invalid_message"
+	ErrorClassGrobid4xx      = "grobid_4xx"
+	ErrorClassGrobid5xx      = "grobid_5xx"
+	ErrorClassS3             = "s3"
+	ErrorClassAWS            = "aws"
+	ErrorClassDB             = "db"
+	ErrorClassParse          = "parse"
+	ErrorClassOther          = "other"
+)
+
+// classifyError names what kind of failure err is, from the errors it wraps
+func classifyError(err error) string {
+	var netErr net.Error
+	var grobidErr *parsing.GrobidError
+	var mysqlErr *mysql.MySQLError
+	var xmlSyntaxErr *xml.SyntaxError
+	var xmlUnmarshalErr xml.UnmarshalError
+	var jsonSyntaxErr *json.SyntaxError
+	var jsonTypeErr *json.UnmarshalTypeError
+	var awsErr awserr.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorClassTimeout
+	case errors.Is(err, parsing.ErrPDFTooLarge), errors.Is(err, errTooLarge):
+		return ErrorClassTooLarge
+	case errors.Is(err, errInvalidOptions):
+		return ErrorClassInvalidOptions
+	case errors.As(err, &grobidErr):
+		if grobidErr.StatusCode >= 500 {
+			return ErrorClassGrobid5xx
+		}
+		return ErrorClassGrobid4xx
+	case errors.Is(err, errS3):
+		return ErrorClassS3
+	case errors.As(err, &mysqlErr), errors.Is(err, sql.ErrNoRows), errors.Is(err, sql.ErrConnDone), errors.Is(err, sql.ErrTxDone), errors.Is(err, driver.ErrBadConn):
+		return ErrorClassDB
+	case errors.As(err, &xmlSyntaxErr), errors.As(err, &xmlUnmarshalErr), errors.As(err, &jsonSyntaxErr), errors.As(err, &jsonTypeErr):
+		return ErrorClassParse
+	case errors.As(err, &awsErr):
+		return ErrorClassAWS
+	}
+	return ErrorClassOther
+}
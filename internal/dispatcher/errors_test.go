@@ -0,0 +1,38 @@
+package dispatcher
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"simple-go-app/internal/parsing"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/go-sql-driver/mysql"
+)
+
+// test errors are classified by what they wrap, not by their message
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{fmt.Errorf("posting: %w", context.DeadlineExceeded), ErrorClassTimeout},
+		{&parsing.GrobidError{StatusCode: 503}, ErrorClassGrobid5xx},
+		{&parsing.GrobidError{StatusCode: 400}, ErrorClassGrobid4xx},
+		{fmt.Errorf("%w: %w", errS3, awserr.New("NoSuchKey", "missing", nil)), ErrorClassS3},
+		{awserr.New("ProvisionedThroughputExceededException", "slow down", nil), ErrorClassAWS},
+		{&mysql.MySQLError{Number: 1054, Message: "Unknown column"}, ErrorClassDB},
+		{xml.Unmarshal([]byte("<TEI"), &struct{}{}), ErrorClassParse},
+		{fmt.Errorf("pdf: %w", parsing.ErrPDFTooLarge), ErrorClassTooLarge},
+		{fmt.Errorf("tei: %w", errTooLarge), ErrorClassTooLarge},
+		{fmt.Errorf("%w: end must not be before start", errInvalidOptions), ErrorClassInvalidOptions},
+		{errors.New("something else"), ErrorClassOther},
+	}
+	for _, test := range tests {
+		if got := classifyError(test.err); got != test.want {
+			t.Errorf("%v: expected %s, got %s", test.err, test.want, got)
+		}
+	}
+}
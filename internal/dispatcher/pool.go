@@ -0,0 +1,511 @@
+package dispatcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"simple-go-app/internal/archive"
+	"simple-go-app/internal/helpers"
+	"simple-go-app/internal/jobs"
+	"simple-go-app/internal/metrics"
+	"simple-go-app/internal/parsing"
+	"simple-go-app/internal/store"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"golang.org/x/sync/semaphore"
+)
+
+// State is what the pool is currently doing with the queue
+type State string
+
+const (
+	// StateStopped is the state before Start is called
+	StateStopped State = "stopped"
+	// StateRunning receives and processes messages
+	StateRunning State = "running"
+	// StatePaused neither receives nor starts messages; messages already received stay buffered
+	StatePaused State = "paused"
+	// StateDraining finishes the messages in flight, hands buffered messages back to SQS and then stops
+	StateDraining State = "draining"
+	// StateDrained is reached once a drain has no messages left in flight
+	StateDrained State = "drained"
+)
+
+// Limits throttle requests to Grobid while it warms up
+type Limits struct {
+	MinimumGapBetweenRequests time.Duration
+	GracePeriodRequests       int
+	GracePeriodWorkers        int
+}
+
+// MarshalJSON reports the gap in seconds, matching the environment variable and the admin API
+func (l Limits) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		MinimumGapBetweenRequestsSeconds float64 `json:"minimum_gap_between_requests_seconds"`
+		GracePeriodRequests              int     `json:"grace_period_requests"`
+		GracePeriodWorkers               int     `json:"grace_period_workers"`
+	}{l.MinimumGapBetweenRequests.Seconds(), l.GracePeriodRequests, l.GracePeriodWorkers})
+}
+
+// Config holds the services a Pool needs to process messages
+type Config struct {
+	SQS       *sqs.SQS
+	SQSURL    string
+	S3Bucket  string
+	AWSRegion string
+	Store     *store.Store
+	Cache     *helpers.CacheHelper
+	Tracker   *jobs.Tracker
+	Grobid    parsing.Grobid
+	// Archive keeps the TEI of every processed paper for offline replays, nil when archiving is off
+	Archive *archive.Archive
+}
+
+// WorkerInfo describes what a worker is doing
+type WorkerInfo struct {
+	ID             int        `json:"id"`
+	Busy           bool       `json:"busy"`
+	Stopping       bool       `json:"stopping"`
+	MessageID      string     `json:"message_id,omitempty"`
+	S3Location     string     `json:"s3_location,omitempty"`
+	Stage          string     `json:"stage,omitempty"`
+	StartedAt      *time.Time `json:"started_at,omitempty"`
+	ElapsedSeconds float64    `json:"elapsed_seconds"`
+	LastHeartbeat  time.Time  `json:"last_heartbeat"`
+}
+
+// Status is a snapshot of the pool
+type Status struct {
+	State         State        `json:"state"`
+	WorkerCount   int          `json:"worker_count"`
+	TotalRequests int64        `json:"total_requests"`
+	Buffered      int          `json:"buffered"`
+	Limits        Limits       `json:"limits"`
+	Workers       []WorkerInfo `json:"workers"`
+}
+
+// worker is the bookkeeping for one worker goroutine, guarded by Pool.mu
+type worker struct {
+	id            int
+	stop          chan struct{}
+	stopping      bool
+	message       *sqs.Message
+	s3Location    string
+	stage         string
+	startedAt     time.Time
+	stageStarted  time.Time
+	lastHeartbeat time.Time
+}
+
+// Pool owns the dispatcher and the workers consuming the requests queue
+type Pool struct {
+	sqsSvc    *sqs.SQS
+	sqsURL    string
+	s3Bucket  string
+	awsRegion string
+	store     *store.Store
+	cacheSvc  *helpers.CacheHelper
+	tracker   *jobs.Tracker
+	grobid    parsing.Grobid
+	// retainPrefix is where processed PDFs and their TEI are kept for reprocessing, empty when they are not kept
+	retainPrefix string
+	archive      *archive.Archive
+	// maxPDFBytes is the size over which a PDF is rejected without being processed
+	maxPDFBytes int64
+
+	messages chan *sqs.Message
+
+	mu           sync.Mutex
+	state        State
+	stateChanged chan struct{}
+	workers      map[int]*worker
+	workerCount  int
+	limits       Limits
+
+	totalRequests     int64
+	lastRequestTime   time.Time
+	lastRequestTimeMu sync.Mutex
+	grobidSemaphore   *semaphore.Weighted
+
+	// process is processMessage, replaced in tests
+	process func(w *worker, message *sqs.Message) error
+}
+
+// NewPool creates a stopped Pool, reading the worker count and rate limits from the environment
+func NewPool(cfg Config) *Pool {
+	minGapBetweenRequests, err := time.ParseDuration(helpers.GetEnvVariable("MINIMUM_GAP_BETWEEN_REQUESTS_SECONDS") + "s")
+	if err != nil {
+		log.Fatalf("Error parsing MINIMUM_GAP_BETWEEN_REQUESTS_SECONDS: %v", err)
+	}
+	gracePeriodRequests, _ := strconv.Atoi(helpers.GetEnvVariable("GRACE_PERIOD_REQUESTS"))
+	allowedWorkers, _ := strconv.Atoi(helpers.GetEnvVariable("GRACE_PERIOD_WORKERS"))
+	numWorkers, _ := strconv.Atoi(helpers.GetEnvVariable("WORKER_COUNT"))
+	retainPrefix := helpers.GetEnvVariableDefault("RETAIN_PREFIX", "retained/")
+	if helpers.GetEnvVariableDefault("RETAIN_PROCESSED_FILES", "true") != "true" {
+		retainPrefix = ""
+	}
+
+	p := &Pool{
+		sqsSvc:    cfg.SQS,
+		sqsURL:    cfg.SQSURL,
+		s3Bucket:  cfg.S3Bucket,
+		awsRegion: cfg.AWSRegion,
+		store:     cfg.Store,
+		cacheSvc:  cfg.Cache,
+		tracker:   cfg.Tracker,
+		grobid:    cfg.Grobid,
+
+		retainPrefix: retainPrefix,
+		archive:      cfg.Archive,
+		maxPDFBytes:  int64(helpers.GetEnvIntDefault("PROCESS_MAX_PDF_MB", 100)) << 20,
+
+		messages: make(chan *sqs.Message, 10), // Adjust the buffer size as needed
+
+		state:        StateStopped,
+		stateChanged: make(chan struct{}),
+		workers:      make(map[int]*worker),
+		workerCount:  numWorkers,
+		limits: Limits{
+			MinimumGapBetweenRequests: minGapBetweenRequests,
+			GracePeriodRequests:       gracePeriodRequests,
+			GracePeriodWorkers:        allowedWorkers,
+		},
+		grobidSemaphore: semaphore.NewWeighted(1),
+	}
+	p.process = p.processMessage
+	return p
+}
+
+// Start launches the dispatcher and the workers. Calling it again is a no-op.
+func (p *Pool) Start() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.state != StateStopped {
+		return
+	}
+	go p.dispatch()
+	p.scale()
+	p.setState(StateRunning)
+}
+
+// Pause stops receiving and starting messages until Resume is called
+func (p *Pool) Pause() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.state != StateRunning {
+		return fmt.Errorf("cannot pause a pool that is %s", p.state)
+	}
+	p.setState(StatePaused)
+	return nil
+}
+
+// Resume continues consuming after a pause or drain
+func (p *Pool) Resume() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.state == StateStopped || p.state == StateRunning {
+		return fmt.Errorf("cannot resume a pool that is %s", p.state)
+	}
+	p.setState(StateRunning)
+	return nil
+}
+
+// Drain stops receiving messages, lets the workers finish the ones in flight and hands buffered ones back to SQS
+func (p *Pool) Drain() error {
+	p.mu.Lock()
+	if p.state != StateRunning && p.state != StatePaused {
+		p.mu.Unlock()
+		return fmt.Errorf("cannot drain a pool that is %s", p.state)
+	}
+	p.setState(StateDraining)
+	p.checkDrained()
+	p.mu.Unlock()
+
+	for {
+		select {
+		case message := <-p.messages:
+			p.release(message)
+		default:
+			return nil
+		}
+	}
+}
+
+// SetWorkerCount starts or stops workers until count are running. Stopped workers finish their current message first.
+func (p *Pool) SetWorkerCount(count int) error {
+	if count < 0 {
+		return fmt.Errorf("worker count cannot be negative")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.workerCount = count
+	if p.state != StateStopped {
+		p.scale()
+	}
+	return nil
+}
+
+// State returns what the pool is currently doing
+func (p *Pool) State() State {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state
+}
+
+// WorkerCount returns the number of workers the pool is configured to run
+func (p *Pool) WorkerCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.workerCount
+}
+
+// SetLimits replaces the rate limits applied to Grobid requests
+func (p *Pool) SetLimits(limits Limits) error {
+	if limits.MinimumGapBetweenRequests < 0 || limits.GracePeriodRequests < 0 || limits.GracePeriodWorkers < 0 {
+		return fmt.Errorf("limits cannot be negative")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.limits = limits
+	return nil
+}
+
+// Status returns a snapshot of the pool and its workers
+func (p *Pool) Status() Status {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.status()
+}
+
+// TryStatus is Status for health checks: it gives up with ctx instead of waiting forever on a deadlocked pool, and
+// starts no goroutine that could be left behind
+func (p *Pool) TryStatus(ctx context.Context) (Status, error) {
+	for !p.mu.TryLock() {
+		select {
+		case <-ctx.Done():
+			return Status{}, ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	defer p.mu.Unlock()
+	return p.status(), nil
+}
+
+// status builds the snapshot returned by Status. Callers hold p.mu.
+func (p *Pool) status() Status {
+	status := Status{
+		State:         p.state,
+		WorkerCount:   p.workerCount,
+		TotalRequests: atomic.LoadInt64(&p.totalRequests),
+		Buffered:      len(p.messages),
+		Limits:        p.limits,
+		Workers:       make([]WorkerInfo, 0, len(p.workers)),
+	}
+	for _, w := range p.workers {
+		info := WorkerInfo{
+			ID:            w.id,
+			Busy:          w.message != nil,
+			Stopping:      w.stopping,
+			LastHeartbeat: w.lastHeartbeat,
+		}
+		if w.message != nil {
+			startedAt := w.startedAt
+			info.MessageID = *w.message.MessageId
+			info.S3Location = w.s3Location
+			info.Stage = w.stage
+			info.StartedAt = &startedAt
+			info.ElapsedSeconds = time.Since(w.startedAt).Seconds()
+		}
+		status.Workers = append(status.Workers, info)
+	}
+	sort.Slice(status.Workers, func(i, j int) bool {
+		return status.Workers[i].ID < status.Workers[j].ID
+	})
+	return status
+}
+
+// Stuck returns the workers that have been busy with the same message for longer than after
+func (s Status) Stuck(after time.Duration) []WorkerInfo {
+	var stuck []WorkerInfo
+	for _, info := range s.Workers {
+		if info.Busy && info.ElapsedSeconds > after.Seconds() {
+			stuck = append(stuck, info)
+		}
+	}
+	return stuck
+}
+
+// gate returns the current state and a channel that is closed the next time the state changes
+func (p *Pool) gate() (State, <-chan struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state, p.stateChanged
+}
+
+// setState wakes up everyone waiting on the gate. Callers hold p.mu.
+func (p *Pool) setState(state State) {
+	log.Printf("Worker pool %s\n", state)
+	p.state = state
+	close(p.stateChanged)
+	p.stateChanged = make(chan struct{})
+}
+
+// checkDrained finishes a drain once no worker has a message in flight. Callers hold p.mu.
+func (p *Pool) checkDrained() {
+	if p.state != StateDraining {
+		return
+	}
+	for _, w := range p.workers {
+		if w.message != nil {
+			return
+		}
+	}
+	p.setState(StateDrained)
+}
+
+// scale starts or stops workers to match workerCount, keeping ids contiguous from 1 so the
+// grace period can tell the first workers apart. Callers hold p.mu.
+func (p *Pool) scale() {
+	running := 0
+	for _, w := range p.workers {
+		if !w.stopping {
+			running++
+		}
+	}
+
+	for id := 1; running < p.workerCount; id++ {
+		if _, ok := p.workers[id]; ok {
+			continue
+		}
+		w := &worker{id: id, stop: make(chan struct{}), lastHeartbeat: time.Now()}
+		p.workers[id] = w
+		running++
+		go p.run(w)
+	}
+	metrics.Workers.Set(float64(len(p.workers)))
+
+	for running > p.workerCount {
+		// stop the worker with the highest id first
+		var highest *worker
+		for _, w := range p.workers {
+			if !w.stopping && (highest == nil || w.id > highest.id) {
+				highest = w
+			}
+		}
+		highest.stopping = true
+		close(highest.stop)
+		running--
+	}
+}
+
+// throttle applies the grace period limits, returning false if the worker should not take a message yet
+func (p *Pool) throttle(id int) bool {
+	p.mu.Lock()
+	limits := p.limits
+	p.mu.Unlock()
+
+	if atomic.LoadInt64(&p.totalRequests) >= int64(limits.GracePeriodRequests) {
+		return true
+	}
+	// if worker id is greater than the allowed workers then return
+	if id > limits.GracePeriodWorkers {
+		return false
+	}
+
+	// Acquire a semaphore before accessing
+	if err := p.grobidSemaphore.Acquire(context.Background(), 1); err != nil {
+		log.Printf("Worker %d could not acquire semaphore: %v\n", id, err)
+		return false
+	}
+	defer p.grobidSemaphore.Release(1) // Release the semaphore when the function exits
+
+	p.lastRequestTimeMu.Lock()
+	timeSinceLastRequest := time.Since(p.lastRequestTime)
+	p.lastRequestTimeMu.Unlock()
+
+	// If the time since the last request is less than the minimum gap between requests, sleep for the difference
+	if timeSinceLastRequest < limits.MinimumGapBetweenRequests {
+		time.Sleep(limits.MinimumGapBetweenRequests - timeSinceLastRequest)
+	}
+	p.lastRequestTimeMu.Lock()
+	p.lastRequestTime = time.Now()
+	p.lastRequestTimeMu.Unlock()
+	return true
+}
+
+// heartbeat records that a worker loop is still turning over
+func (p *Pool) heartbeat(w *worker) {
+	p.mu.Lock()
+	w.lastHeartbeat = time.Now()
+	p.mu.Unlock()
+}
+
+// begin marks a worker as busy with a message
+func (p *Pool) begin(w *worker, message *sqs.Message) {
+	p.mu.Lock()
+	w.message = message
+	w.s3Location = ""
+	w.stage = jobs.StageQueued
+	w.startedAt = time.Now()
+	w.stageStarted = w.startedAt
+	p.mu.Unlock()
+	p.tracker.Start(*message.MessageId)
+	metrics.WorkersBusy.Inc()
+	metrics.BufferedMessages.Set(float64(len(p.messages)))
+}
+
+// setStage records the pipeline stage of the worker's current message, timing the stage it leaves
+func (p *Pool) setStage(w *worker, stage string) {
+	p.mu.Lock()
+	p.observeStage(w)
+	w.stage = stage
+	message := w.message
+	p.mu.Unlock()
+	if message != nil {
+		p.tracker.SetStage(*message.MessageId, stage)
+	}
+}
+
+// observeStage records how long the worker spent in its current stage. Callers hold p.mu.
+func (p *Pool) observeStage(w *worker) {
+	now := time.Now()
+	if w.stage != "" && w.stage != jobs.StageQueued {
+		metrics.StageDuration.WithLabelValues(w.stage).Observe(now.Sub(w.stageStarted).Seconds())
+	}
+	w.stageStarted = now
+}
+
+// setLocation records the s3 location of the worker's current message
+func (p *Pool) setLocation(w *worker, s3Location string) {
+	p.mu.Lock()
+	w.s3Location = s3Location
+	p.mu.Unlock()
+}
+
+// end marks a worker as idle again
+func (p *Pool) end(w *worker) {
+	p.mu.Lock()
+	p.observeStage(w)
+	w.message = nil
+	w.s3Location = ""
+	w.stage = ""
+	w.lastHeartbeat = time.Now()
+	p.checkDrained()
+	p.mu.Unlock()
+	metrics.WorkersBusy.Dec()
+}
+
+// exit forgets a worker that has stopped
+func (p *Pool) exit(w *worker) {
+	p.mu.Lock()
+	delete(p.workers, w.id)
+	metrics.Workers.Set(float64(len(p.workers)))
+	p.mu.Unlock()
+	log.Printf("Worker %d stopped\n", w.id)
+}
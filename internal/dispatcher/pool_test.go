@@ -0,0 +1,293 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"simple-go-app/internal/jobs"
+	"simple-go-app/internal/metrics"
+	"simple-go-app/internal/parsing"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/sync/semaphore"
+)
+
+// testPool is a pool whose workers block on each message until it is let through
+type testPool struct {
+	*Pool
+	sqsCalls chan string
+	proceed  chan struct{}
+	// fail is returned by every message when set
+	fail error
+
+	mu        sync.Mutex
+	processed []string
+}
+
+func newTestPool(t *testing.T, workers int) *testPool {
+	tp := &testPool{sqsCalls: make(chan string, 100), proceed: make(chan struct{})}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tp.sqsCalls <- r.Header.Get("X-Amz-Target")
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		w.Write([]byte("{}"))
+	}))
+	t.Cleanup(server.Close)
+	sess := session.Must(session.NewSession(&aws.Config{
+		Region:      aws.String("eu-west-1"),
+		Endpoint:    aws.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+	}))
+
+	tp.Pool = &Pool{
+		sqsSvc:          sqs.New(sess),
+		sqsURL:          server.URL + "/queue",
+		tracker:         jobs.NewTracker(time.Hour),
+		messages:        make(chan *sqs.Message, 10),
+		state:           StateStopped,
+		stateChanged:    make(chan struct{}),
+		workers:         make(map[int]*worker),
+		workerCount:     workers,
+		grobidSemaphore: semaphore.NewWeighted(1),
+	}
+	tp.process = func(w *worker, message *sqs.Message) error {
+		<-tp.proceed
+		if tp.fail != nil {
+			return tp.fail
+		}
+		tp.mu.Lock()
+		tp.processed = append(tp.processed, *message.MessageId)
+		tp.mu.Unlock()
+		tp.tracker.Succeed(*message.MessageId, 1, false)
+		return nil
+	}
+	t.Cleanup(func() {
+		close(tp.proceed)
+		tp.SetWorkerCount(0)
+	})
+
+	// what Start does, without receiving from SQS
+	tp.Pool.mu.Lock()
+	tp.scale()
+	tp.setState(StateRunning)
+	tp.Pool.mu.Unlock()
+	return tp
+}
+
+// send queues a message as the dispatcher would
+func (tp *testPool) send(id string) {
+	tp.sendBody(id, `{"s3Location": "uploads/`+id+`.pdf", "user_id": "3", "screen_id": "7"}`)
+}
+
+func (tp *testPool) sendBody(id, body string) {
+	tp.tracker.Enqueue(id, body)
+	tp.messages <- &sqs.Message{MessageId: aws.String(id), ReceiptHandle: aws.String("receipt-" + id), Body: aws.String(body)}
+}
+
+func (tp *testPool) processedCount() int {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	return len(tp.processed)
+}
+
+func (tp *testPool) busy() int {
+	busy := 0
+	for _, info := range tp.Status().Workers {
+		if info.Busy {
+			busy++
+		}
+	}
+	return busy
+}
+
+// waitFor polls condition until it holds or a few seconds have passed
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// test workers are added and removed while busy, a stopped worker finishing its message first
+func TestPool_SetWorkerCount(t *testing.T) {
+	tp := newTestPool(t, 2)
+	tp.send("a")
+	tp.send("b")
+	waitFor(t, "both workers to be busy", func() bool { return tp.busy() == 2 })
+
+	if err := tp.SetWorkerCount(1); err != nil {
+		t.Fatal(err)
+	}
+	status := tp.Status()
+	if len(status.Workers) != 2 || !status.Workers[1].Stopping || !status.Workers[1].Busy || status.Workers[0].Stopping {
+		t.Fatalf("Expected worker 2 to stop after its message, got %+v", status.Workers)
+	}
+
+	tp.proceed <- struct{}{}
+	tp.proceed <- struct{}{}
+	waitFor(t, "worker 2 to exit", func() bool { return len(tp.Status().Workers) == 1 })
+	if tp.processedCount() != 2 {
+		t.Errorf("Expected both messages to be processed, got %d", tp.processedCount())
+	}
+
+	if err := tp.SetWorkerCount(3); err != nil {
+		t.Fatal(err)
+	}
+	status = tp.Status()
+	if len(status.Workers) != 3 || status.Workers[2].ID != 3 {
+		t.Errorf("Expected workers 1 to 3, got %+v", status.Workers)
+	}
+	if err := tp.SetWorkerCount(-1); err == nil {
+		t.Errorf("Expected an error for a negative count")
+	}
+}
+
+// test a paused pool starts no message until it is resumed
+func TestPool_PauseResume(t *testing.T) {
+	tp := newTestPool(t, 1)
+	if err := tp.Pause(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tp.Pause(); err == nil {
+		t.Errorf("Expected pausing twice to fail")
+	}
+
+	tp.send("a")
+	time.Sleep(1500 * time.Millisecond)
+	if tp.busy() != 0 || tp.Status().Buffered != 1 {
+		t.Fatalf("Expected the message to stay buffered while paused, got %+v", tp.Status())
+	}
+
+	if err := tp.Resume(); err != nil {
+		t.Fatal(err)
+	}
+	tp.proceed <- struct{}{}
+	waitFor(t, "the message to be processed", func() bool { return tp.processedCount() == 1 })
+	if err := tp.Resume(); err == nil {
+		t.Errorf("Expected resuming a running pool to fail")
+	}
+}
+
+// test a drain finishes the message in flight, hands the buffered one back to SQS and leaves the counts consistent
+func TestPool_Drain(t *testing.T) {
+	tp := newTestPool(t, 1)
+	tp.send("a")
+	waitFor(t, "the worker to be busy", func() bool { return tp.busy() == 1 })
+	tp.send("b")
+
+	if err := tp.Drain(); err != nil {
+		t.Fatal(err)
+	}
+	if state := tp.State(); state != StateDraining {
+		t.Fatalf("Expected draining with a message in flight, got %s", state)
+	}
+	select {
+	case target := <-tp.sqsCalls:
+		if target != "AmazonSQS.ChangeMessageVisibility" {
+			t.Errorf("Unexpected SQS call %s", target)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("The buffered message was not released")
+	}
+	if _, ok := tp.tracker.Get("b"); ok {
+		t.Errorf("Expected the released job to be forgotten")
+	}
+
+	tp.proceed <- struct{}{}
+	waitFor(t, "the drain to finish", func() bool { return tp.State() == StateDrained })
+
+	progress := tp.tracker.Progress(7, 0, 1)
+	if progress.Queued != 0 || progress.InProgress != 0 || progress.Succeeded != 1 {
+		t.Errorf("Unexpected progress after the drain: %+v", progress)
+	}
+	if status := tp.Status(); status.Buffered != 0 || tp.busy() != 0 {
+		t.Errorf("Unexpected status after the drain: %+v", status)
+	}
+	if err := tp.Drain(); err == nil {
+		t.Errorf("Expected draining a drained pool to fail")
+	}
+	if err := tp.Resume(); err != nil {
+		t.Errorf("Expected a drained pool to resume: %v", err)
+	}
+}
+
+// test TryStatus gives up on a deadlocked pool without leaving a goroutine behind
+func TestPool_TryStatus(t *testing.T) {
+	tp := newTestPool(t, 1)
+	if _, err := tp.TryStatus(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	tp.Pool.mu.Lock()
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	_, err := tp.TryStatus(ctx)
+	cancel()
+	after := runtime.NumGoroutine()
+	tp.Pool.mu.Unlock()
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline to be exceeded, got %v", err)
+	}
+	if after > before {
+		t.Errorf("Expected no goroutine to be left behind, %d before and %d after", before, after)
+	}
+}
+
+// test a failed message is counted under the class of its error and the job records it
+func TestPool_FailureMetrics(t *testing.T) {
+	tp := newTestPool(t, 1)
+	tp.fail = fmt.Errorf("sending to grobid: %w", context.DeadlineExceeded)
+	timeouts := metrics.MessagesProcessed.WithLabelValues(metrics.OutcomeFailed, ErrorClassTimeout)
+	before := testutil.ToFloat64(timeouts)
+
+	// a reprocess message, whose failure is only deleted from the queue
+	tp.sendBody("a", `{"s3Location": "retained/1.pdf", "paper_id": "1", "source": "pdf"}`)
+	tp.proceed <- struct{}{}
+	waitFor(t, "the job to fail", func() bool {
+		job, _ := tp.tracker.Get("a")
+		return job.Status == jobs.StatusFailed
+	})
+
+	if got := testutil.ToFloat64(timeouts) - before; got != 1 {
+		t.Errorf("Expected one timeout to be counted, got %v", got)
+	}
+	select {
+	case target := <-tp.sqsCalls:
+		if target != "AmazonSQS.DeleteMessage" {
+			t.Errorf("Unexpected SQS call %s", target)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("The failed message was not deleted")
+	}
+}
+
+// test a message already retried once is deleted, not left to be retried again, when its PDF is too large
+func TestPool_OversizeAfterRetry(t *testing.T) {
+	tp := newTestPool(t, 1)
+	tp.fail = fmt.Errorf("uploads/a.pdf: %w", parsing.ErrPDFTooLarge)
+
+	tp.sendBody("a", `{"s3Location": "uploads/a.pdf", "user_id": "3", "screen_id": "7", "decrement": true}`)
+	tp.proceed <- struct{}{}
+	select {
+	case target := <-tp.sqsCalls:
+		if target != "AmazonSQS.DeleteMessage" {
+			t.Errorf("Unexpected SQS call %s", target)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("The failed message was not deleted")
+	}
+}
@@ -0,0 +1,194 @@
+package dispatcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"simple-go-app/internal/jobs"
+	"simple-go-app/internal/logging"
+	"simple-go-app/internal/metrics"
+	"simple-go-app/internal/parsing"
+	"simple-go-app/internal/store"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sqs"
+)
+
+// Sources a paper can be reprocessed from
+const (
+	// SourcePDF sends the retained PDF through Grobid again, picking up Grobid upgrades
+	SourcePDF = "pdf"
+	// SourceTEI reparses the retained TEI, picking up parser changes without calling Grobid
+	SourceTEI = "tei"
+)
+
+// EnqueueReprocess queues an existing paper to be extracted again from what was retained when it was first processed.
+// Unlike Enqueue it does not count towards the screen's papers processing.
+func (p *Pool) EnqueueReprocess(paper store.Paper, source string) (jobs.Job, error) {
+	if source != SourcePDF && source != SourceTEI {
+		return jobs.Job{}, fmt.Errorf("unknown source %q", source)
+	}
+	if p.retainPrefix == "" {
+		return jobs.Job{}, fmt.Errorf("RETAIN_PROCESSED_FILES is off, nothing is retained to reprocess from")
+	}
+
+	body, err := json.Marshal(Request{
+		S3Location: p.retainedKey(paper.ID, source),
+		UserID:     strconv.FormatInt(paper.UserID, 10),
+		ScreenID:   strconv.FormatInt(paper.ScreenID, 10),
+		PaperID:    strconv.FormatInt(paper.ID, 10),
+		Source:     source,
+	})
+	if err != nil {
+		return jobs.Job{}, err
+	}
+
+	result, err := p.sqsSvc.SendMessage(&sqs.SendMessageInput{
+		MessageBody: aws.String(string(body)),
+		QueueUrl:    aws.String(p.sqsURL),
+	})
+	if err != nil {
+		return jobs.Job{}, err
+	}
+
+	p.tracker.Enqueue(*result.MessageId, string(body))
+	job, _ := p.tracker.Get(*result.MessageId)
+	return job, nil
+}
+
+// retainedKey is where the PDF or TEI of a paper is kept
+func (p *Pool) retainedKey(paperID int64, source string) string {
+	if source == SourceTEI {
+		return fmt.Sprintf("%s%d.tei.xml", p.retainPrefix, paperID)
+	}
+	return fmt.Sprintf("%s%d.pdf", p.retainPrefix, paperID)
+}
+
+// retain copies a processed upload and stores its TEI under the paper's id
+func (p *Pool) retain(s3Svc *s3.S3, path string, paperID int64, tei []byte) error {
+	if p.retainPrefix == "" || paperID == 0 {
+		return nil
+	}
+
+	_, err := s3Svc.CopyObject(&s3.CopyObjectInput{
+		Bucket:     aws.String(p.s3Bucket),
+		CopySource: aws.String((&url.URL{Path: p.s3Bucket + "/" + path}).EscapedPath()),
+		Key:        aws.String(p.retainedKey(paperID, SourcePDF)),
+	})
+	if err != nil {
+		return err
+	}
+	return p.retainTEI(s3Svc, paperID, tei)
+}
+
+// retainTEI stores the TEI Grobid returned for a paper
+func (p *Pool) retainTEI(s3Svc *s3.S3, paperID int64, tei []byte) error {
+	_, err := s3Svc.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(p.s3Bucket),
+		Key:         aws.String(p.retainedKey(paperID, SourceTEI)),
+		Body:        bytes.NewReader(tei),
+		ContentType: aws.String("application/xml"),
+	})
+	return err
+}
+
+// reprocess extracts a paper again from its retained PDF or TEI and replaces its sections, abstract parts, authors,
+// references and figures
+func (p *Pool) reprocess(w *worker, message *sqs.Message) error {
+	var request Request
+	if err := json.Unmarshal([]byte(*message.Body), &request); err != nil {
+		return err
+	}
+	paperID, err := strconv.ParseInt(request.PaperID, 10, 64)
+	if err != nil {
+		p.invalidMessage(message, "paper_id")
+		return nil
+	}
+	p.setLocation(w, request.S3Location)
+
+	paper, err := p.store.FindPaperByID(paperID)
+	if err != nil {
+		return fmt.Errorf("finding paper %d: %w", paperID, err)
+	}
+
+	s3Svc := s3.New(createAWSSession(p.awsRegion))
+	p.setStage(w, jobs.StageDownload)
+	var crudeGrobidResponse *parsing.CrudeGrobidResponse
+	var pdf *parsing.HashingPDF
+	if request.Source == SourceTEI {
+		content, err := downloadFileFromS3(s3Svc, p.s3Bucket, request.S3Location, maxTEIBytes)
+		if err != nil {
+			return fmt.Errorf("downloading %s: %w", request.S3Location, err)
+		}
+		crudeGrobidResponse, err = parsing.ParseGrobidResponse(content)
+		if err != nil {
+			return err
+		}
+	} else {
+		opts, err := p.grobidOptions(paper.ScreenID, request.GrobidOptions)
+		if err != nil {
+			return err
+		}
+		open, err := p.openPDFFromS3(context.Background(), s3Svc, request.S3Location)
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", request.S3Location, err)
+		}
+		pdf = parsing.NewHashingPDF(open)
+		p.setStage(w, jobs.StageGrobid)
+		crudeGrobidResponse, err = parsing.SendPDF2Grobid(context.Background(), p.grobid, pdf.Open, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	tidyGrobidResponse, err := parsing.TidyUpGrobidResponse(crudeGrobidResponse)
+	if err != nil {
+		return err
+	}
+	pdfDTO := parsing.CreatePDFDTO(tidyGrobidResponse, nil)
+	// the paper keeps its abstract, which may have come from Crossref
+	if paper.Abstract != "" {
+		pdfDTO.Abstract = paper.Abstract
+	}
+
+	p.setStage(w, jobs.StagePersist)
+	sections := buildSections(pdfDTO)
+	changes, err := p.store.ReplaceExtraction(paper.ID, paper.ScreenID, sections, pdfDTO)
+	if err != nil {
+		return err
+	}
+	logging.InfoLogger.Printf("Reprocessed paper %d: %+v, %d references\n", paper.ID, changes, len(pdfDTO.References))
+	metrics.SectionsWritten.Add(float64(changes.Inserted))
+
+	// a new Grobid run replaces the retained TEI so the next reparse starts from it, and is archived next to the
+	// earlier runs
+	if request.Source == SourcePDF {
+		if err := p.retainTEI(s3Svc, paper.ID, crudeGrobidResponse.TEI); err != nil {
+			log.Println("Error storing TEI in S3:", err)
+		}
+		if p.archive != nil {
+			if err := p.archive.Save(context.Background(), paper.ID, pdf.Open, pdf.SHA256(), crudeGrobidResponse.TEI); err != nil {
+				logging.ErrorLogger.Println("Error archiving TEI:", err)
+			}
+		}
+	}
+
+	_, err = p.sqsSvc.DeleteMessage(&sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(p.sqsURL),
+		ReceiptHandle: message.ReceiptHandle,
+	})
+	if err != nil {
+		log.Println("Error deleting message:", err)
+	} else {
+		metrics.MessagesAcked.WithLabelValues("delete").Inc()
+	}
+
+	p.tracker.Succeed(*message.MessageId, paper.ID, false)
+	metrics.MessagesProcessed.WithLabelValues(metrics.OutcomeSucceeded, "").Inc()
+	return nil
+}
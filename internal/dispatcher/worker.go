@@ -3,101 +3,95 @@ package dispatcher
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"log"
 	"os"
 	"simple-go-app/internal/helpers"
+	"simple-go-app/internal/jobs"
 	"simple-go-app/internal/logging"
+	"simple-go-app/internal/metrics"
 	"simple-go-app/internal/parsing"
 	"simple-go-app/internal/store"
 	"strconv"
 	"strings"
-	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sqs"
-	"golang.org/x/sync/semaphore"
 )
 
-var (
-	lastRequestTime   time.Time
-	lastRequestTimeMu sync.Mutex
-	totalRequests     = 0
-	grobidSemaphore   = semaphore.NewWeighted(1)
-)
-
-func Worker(id int, messageQueue <-chan *sqs.Message, svc *sqs.SQS, sqsURL, s3Bucket string, s *store.Store, cacheSvc *helpers.CacheHelper) {
-	awsRegion := helpers.GetEnvVariable("AWS_REGION")
-	minGapBetweenRequests, err := time.ParseDuration(helpers.GetEnvVariable("MINIMUM_GAP_BETWEEN_REQUESTS_SECONDS") + "s")
-	if err != nil {
-		log.Fatalf("Error parsing MINIMUM_GAP_BETWEEN_REQUESTS_SECONDS: %v", err)
-	}
-	gracePeriodRequests, _ := strconv.Atoi(helpers.GetEnvVariable("GRACE_PERIOD_REQUESTS"))
-	allowedWorkers, _ := strconv.Atoi(helpers.GetEnvVariable("GRACE_PERIOD_WORKERS"))
-
-	log.Printf("Starting worker %d...\n", id)
+// run is the loop of a single worker, taking messages off the queue while the pool is running
+func (p *Pool) run(w *worker) {
+	defer p.exit(w)
+	log.Printf("Starting worker %d...\n", w.id)
 
 	for {
-		pass := true
-
-		if totalRequests < gracePeriodRequests {
-			// if worker id is greater than the allowed workers then return
-			if id > allowedWorkers {
-				pass = false
-			}
-			if pass {
-				// Acquire a semaphore before accessing
-				if err := grobidSemaphore.Acquire(context.Background(), 1); err != nil {
-					log.Printf("Worker %d could not acquire semaphore: %v\n", id, err)
-					pass = false
-				}
-				lastRequestTimeMu.Lock()
-				timeSinceLastRequest := time.Since(lastRequestTime)
-				lastRequestTimeMu.Unlock()
-				//log.Printf("Worker %d acquired semaphore\n", id)
-
-				// If the time since the last request is less than the minimum gap between requests, sleep for the difference
-				if timeSinceLastRequest < minGapBetweenRequests {
-					sleepTime := minGapBetweenRequests - timeSinceLastRequest
-					//log.Printf("Worker %d sleeping for %v to meet the minimum gap between requests\n", id, sleepTime)
-					time.Sleep(sleepTime)
-				}
-				lastRequestTimeMu.Lock()
-				lastRequestTime = time.Now()
-				lastRequestTimeMu.Unlock()
-				grobidSemaphore.Release(1) // Release the semaphore when the function exits
-				//log.Printf("Worker %d releasing semaphore\n", id)
+		p.heartbeat(w)
+		state, changed := p.gate()
+		if state != StateRunning {
+			// paused or drained, idle until the state changes or we are told to stop
+			select {
+			case <-changed:
+				continue
+			case <-w.stop:
+				return
 			}
 		}
 
-		if pass {
-
-			message := <-messageQueue
-			err := processMessage(id, message, svc, sqsURL, s3Bucket, awsRegion, s, cacheSvc)
-			if err != nil {
-				logging.ErrorLogger.Println(err)
-				err := handleFail(s, cacheSvc, message, svc, sqsURL, err)
-				if err != nil {
-					logging.ErrorLogger.Println(err)
-				}
+		if p.throttle(w.id) {
+			select {
+			case <-changed:
+				continue
+			case <-w.stop:
+				return
+			case message := <-p.messages:
+				p.handle(w, message)
 			}
 		}
 		time.Sleep(1 * time.Second)
 	}
 }
 
+// handle processes a message and records its outcome
+func (p *Pool) handle(w *worker, message *sqs.Message) {
+	p.begin(w, message)
+	defer p.end(w)
+
+	err := p.process(w, message)
+	if err != nil {
+		logging.ErrorLogger.Println(err)
+		p.tracker.Fail(*message.MessageId, err)
+		metrics.MessagesProcessed.WithLabelValues(metrics.OutcomeFailed, classifyError(err)).Inc()
+		err := handleFail(p.store, p.cacheSvc, message, p.sqsSvc, p.sqsURL, err)
+		if err != nil {
+			logging.ErrorLogger.Println(err)
+		}
+	}
+}
+
 func handleFail(s *store.Store, cacheSvc *helpers.CacheHelper, message *sqs.Message, sqsSvc *sqs.SQS, sqsURL string, err error) error {
 	logging.ErrorLogger.Printf("HANDLING FAILED MESSAGE: %s\n", *message.MessageId)
+	// an oversize PDF or invalid options fail the same way every time, so they are logged for the user but not retried
+	retry := !errors.Is(err, parsing.ErrPDFTooLarge) && !errors.Is(err, errInvalidOptions)
 
 	var msgData map[string]interface{}
 	if err1 := json.Unmarshal([]byte(*message.Body), &msgData); err1 != nil {
 		return err1
 	}
+	// reprocessing never counted towards the screen, so there is nothing to decrement and no point retrying
+	if _, ok := msgData["paper_id"]; ok {
+		_, err1 := sqsSvc.DeleteMessage(&sqs.DeleteMessageInput{
+			QueueUrl:      aws.String(sqsURL),
+			ReceiptHandle: message.ReceiptHandle,
+		})
+		return err1
+	}
+
 	// check if message does NOT have the decrement field
 	decrement, ok := msgData["decrement"]
 	//logging.ErrorLogger.Printf("Decrement: %v\n", decrement)
@@ -127,7 +121,7 @@ func handleFail(s *store.Store, cacheSvc *helpers.CacheHelper, message *sqs.Mess
 			return err
 		}
 
-		key := fmt.Sprintf("rapidresearch_cache_:screen:%d:papers_processing", screenID)
+		key := helpers.ScreenProcessingKey(screenID)
 		// decrement the cache with the screen id
 		err = cacheSvc.DecrOrDeleteCache(key)
 		if err != nil {
@@ -145,6 +139,9 @@ func handleFail(s *store.Store, cacheSvc *helpers.CacheHelper, message *sqs.Mess
 			QueueUrl:      aws.String(sqsURL),
 			ReceiptHandle: message.ReceiptHandle,
 		})
+		if !retry {
+			return err
+		}
 
 		// send message onto queue
 		_, err = sqsSvc.SendMessage(&sqs.SendMessageInput{
@@ -155,10 +152,148 @@ func handleFail(s *store.Store, cacheSvc *helpers.CacheHelper, message *sqs.Mess
 		if err != nil {
 			return err
 		}
+		metrics.MessagesAcked.WithLabelValues("retry").Inc()
+	} else if !retry {
+		// a message that was retried for another failure is still not retried again
+		_, err := sqsSvc.DeleteMessage(&sqs.DeleteMessageInput{
+			QueueUrl:      aws.String(sqsURL),
+			ReceiptHandle: message.ReceiptHandle,
+		})
+		return err
 	}
 	return nil
 }
 
+// errInvalidOptions marks Grobid options that fail validation, which retrying will not fix
+var errInvalidOptions = errors.New("invalid grobid options")
+
+// grobidOptions resolves the options a PDF is processed with, those of the request over those of the screen over
+// parsing.FulltextOptions. request may be nil.
+func (p *Pool) grobidOptions(screenID int64, request *parsing.GrobidOptions) (parsing.ProcessOptions, error) {
+	screen, err := p.store.FindScreenGrobidOptions(screenID)
+	if err != nil {
+		return parsing.ProcessOptions{}, fmt.Errorf("finding the Grobid options of screen %d: %w", screenID, err)
+	}
+	var requested parsing.GrobidOptions
+	if request != nil {
+		requested = *request
+	}
+	opts, err := parsing.ResolveOptions(screen, requested)
+	if err != nil {
+		return parsing.ProcessOptions{}, fmt.Errorf("%w: %v", errInvalidOptions, err)
+	}
+	return opts, nil
+}
+
+// saveExtraction writes the abstract parts, authors, references, figures and citations of a new paper, whose
+// sections have been written with their IDs set in sections
+func (p *Pool) saveExtraction(paperID, screenID int64, pdfDTO *parsing.PDFDTO, sections []store.Section) {
+	if err := p.store.ReplaceAbstractParts(paperID, pdfDTO.AbstractParts); err != nil {
+		logging.ErrorLogger.Println("NON-FATAL: Error saving abstract parts:", err)
+	}
+	if err := p.store.ReplacePaperAuthors(paperID, screenID, pdfDTO.Authors); err != nil {
+		logging.ErrorLogger.Println("NON-FATAL: Error saving authors:", err)
+	} else {
+		log.Printf("Authors saved: %d\n", len(pdfDTO.Authors))
+	}
+	if err := p.store.ReplaceReferences(paperID, pdfDTO.References); err != nil {
+		logging.ErrorLogger.Println("NON-FATAL: Error saving references:", err)
+	} else {
+		log.Printf("References saved: %d\n", len(pdfDTO.References))
+	}
+	if err := p.store.ReplaceFigures(paperID, pdfDTO.Figures); err != nil {
+		logging.ErrorLogger.Println("NON-FATAL: Error saving figures:", err)
+	} else {
+		log.Printf("Figures saved: %d\n", len(pdfDTO.Figures))
+	}
+	if err := p.store.ReplaceCitations(paperID, sections); err != nil {
+		logging.ErrorLogger.Println("NON-FATAL: Error saving citations:", err)
+	}
+}
+
+// requestedOptions reads the grobid_options of a message, nil when it has none. Only a grobid_options that does not
+// decode is errInvalidOptions, other fields of the message failing to decode are not.
+func requestedOptions(body []byte) (*parsing.GrobidOptions, error) {
+	var request struct {
+		GrobidOptions json.RawMessage `json:"grobid_options"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, err
+	}
+	var requested *parsing.GrobidOptions
+	if len(request.GrobidOptions) > 0 {
+		if err := json.Unmarshal(request.GrobidOptions, &requested); err != nil {
+			return nil, fmt.Errorf("%w: %v", errInvalidOptions, err)
+		}
+	}
+	return requested, nil
+}
+
+// invalidMessage records a message that cannot be processed because a required field is missing
+func (p *Pool) invalidMessage(message *sqs.Message, field string) {
+	log.Printf("Message missing %s field\n", field)
+	p.tracker.Fail(*message.MessageId, fmt.Errorf("message missing %s field", field))
+	metrics.MessagesProcessed.WithLabelValues(metrics.OutcomeFailed, ErrorClassInvalidMessage).Inc()
+}
+
+// buildSections turns an extraction into the rows of the sections table, starting with the abstract.
+// Each row points to the first row of the enclosing section, so a heading with subsections but no paragraphs of its own
+// gets a row holding the heading.
+func buildSections(pdfDTO *parsing.PDFDTO) []store.Section {
+	sections := []store.Section{
+		{
+			Header: "abstract",
+			Depth:  1,
+			Text:   pdfDTO.Abstract,
+		},
+	}
+
+	hasSubsections := make(map[int]bool)
+	for _, section := range pdfDTO.Sections {
+		if section.Parent >= 0 {
+			hasSubsections[section.Parent] = true
+		}
+	}
+	// firstRows holds the row each section starts at, -1 for sections without rows
+	firstRows := make([]int, len(pdfDTO.Sections))
+	for i, section := range pdfDTO.Sections {
+		firstRows[i] = -1
+		paragraphs := section.Paragraphs
+		if len(paragraphs) == 0 {
+			if !hasSubsections[i] || section.Head == "" {
+				continue
+			}
+			paragraphs = []parsing.ParagraphRaw{{Text: section.Head}}
+		}
+
+		var parent *int
+		if section.Parent >= 0 && firstRows[section.Parent] >= 0 {
+			parent = &firstRows[section.Parent]
+		}
+		var number *string
+		if section.Number != "" {
+			value := section.Number
+			number = &value
+		}
+		firstRows[i] = len(sections)
+		header := strings.ToLower(section.Head)
+
+		for _, p := range paragraphs {
+			paragraph := store.Section{
+				Header:      header,
+				Number:      number,
+				Depth:       int64(section.Depth),
+				ParentIndex: parent,
+				Text:        p.Text,
+				Citations:   p.Citations,
+			}
+			paragraph.SetBoxes(p.Boxes())
+			sections = append(sections, paragraph)
+		}
+	}
+	return sections
+}
+
 func createAWSSession(region string) *session.Session {
 	sess := session.Must(session.NewSession(&aws.Config{
 		Region: aws.String(region),
@@ -166,13 +301,20 @@ func createAWSSession(region string) *session.Session {
 	return sess
 }
 
-func downloadFileFromS3(s3Svc *s3.S3, bucket, path string) ([]byte, error) {
+// maxTEIBytes is the largest TEI read into memory, well over what Grobid returns for a PDF under PROCESS_MAX_PDF_MB
+const maxTEIBytes = 256 << 20
+
+// errTooLarge marks a file downloaded from S3 that is over the limit it was read with
+var errTooLarge = errors.New("file is too large")
+
+// downloadFileFromS3 reads a file into memory, failing with errTooLarge once more than maxBytes have been read
+func downloadFileFromS3(s3Svc *s3.S3, bucket, path string, maxBytes int64) ([]byte, error) {
 	output, err := s3Svc.GetObject(&s3.GetObjectInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(path),
 	})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", errS3, err)
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
@@ -181,16 +323,61 @@ func downloadFileFromS3(s3Svc *s3.S3, bucket, path string) ([]byte, error) {
 		}
 	}(output.Body)
 
-	fileContent, err := ioutil.ReadAll(output.Body)
+	fileContent, err := io.ReadAll(io.LimitReader(output.Body, maxBytes+1))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", errS3, err)
+	}
+	if int64(len(fileContent)) > maxBytes {
+		return nil, fmt.Errorf("%s is over the limit of %d bytes: %w", path, maxBytes, errTooLarge)
 	}
 	return fileContent, nil
 }
 
-func processMessage(id int, message *sqs.Message, svc *sqs.SQS, sqsURL, s3Bucket, awsRegion string, s *store.Store, cacheSvc *helpers.CacheHelper) error {
+// openPDFFromS3 checks the size of a PDF with HeadObject, failing with parsing.ErrPDFTooLarge when it is over the
+// limit, and returns an opener streaming it from S3, so a worker never holds a whole PDF in memory
+func (p *Pool) openPDFFromS3(ctx context.Context, s3Svc *s3.S3, path string) (parsing.PDFOpener, error) {
+	head, err := s3Svc.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(p.s3Bucket),
+		Key:    aws.String(path),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errS3, err)
+	}
+	if size := aws.Int64Value(head.ContentLength); size > p.maxPDFBytes {
+		return nil, fmt.Errorf("%s is %d bytes, over the limit of %d bytes: %w", path, size, p.maxPDFBytes, parsing.ErrPDFTooLarge)
+	}
+
+	open := func(ctx context.Context) (io.ReadCloser, error) {
+		output, err := s3Svc.GetObjectWithContext(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(p.s3Bucket),
+			Key:    aws.String(path),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errS3, err)
+		}
+		return output.Body, nil
+	}
+	// the object may have been replaced by a bigger one since HeadObject
+	return parsing.LimitPDF(open, p.maxPDFBytes), nil
+}
+
+// deleteOversizeUpload deletes an upload that failed with parsing.ErrPDFTooLarge, as it is not retried
+func (p *Pool) deleteOversizeUpload(s3Svc *s3.S3, path string, err error) {
+	if !errors.Is(err, parsing.ErrPDFTooLarge) {
+		return
+	}
+	_, err = s3Svc.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(p.s3Bucket),
+		Key:    aws.String(path),
+	})
+	if err != nil {
+		log.Println("Error deleting oversize file from S3:", err)
+	}
+}
+
+func (p *Pool) processMessage(w *worker, message *sqs.Message) error {
 	defer func() {
-		totalRequests++
+		totalRequests := atomic.AddInt64(&p.totalRequests, 1)
 		log.Printf("Total requests: %d\n", totalRequests)
 	}()
 	var msgData map[string]interface{}
@@ -199,43 +386,64 @@ func processMessage(id int, message *sqs.Message, svc *sqs.SQS, sqsURL, s3Bucket
 		return err
 	}
 
+	if _, ok := msgData["paper_id"]; ok {
+		return p.reprocess(w, message)
+	}
+
 	// check if message has all the required fields if not return error
 	if _, ok := msgData["s3Location"]; !ok {
-		log.Println("Message missing s3Location field")
+		p.invalidMessage(message, "s3Location")
 		return nil
 	}
 
 	if _, ok := msgData["user_id"]; !ok {
-		log.Println("Message missing user_id field")
+		p.invalidMessage(message, "user_id")
 		return nil
 	}
 
 	if _, ok := msgData["screen_id"]; !ok {
-		log.Println("Message missing screen_id field")
+		p.invalidMessage(message, "screen_id")
 		return nil
 	}
 
 	path := msgData["s3Location"].(string)
+	p.setLocation(w, path)
 	userIDTemp := msgData["user_id"].(string)
 	userID, err := strconv.ParseInt(userIDTemp, 10, 64)
 	screenIDTemp := msgData["screen_id"].(string)
 	screenID, err := strconv.ParseInt(screenIDTemp, 10, 64)
 
-	fmt.Printf("Worker %d received message. Path: %s. User ID: %s. Screen ID: %s\n", id, path, userID, screenIDTemp)
+	fmt.Printf("Worker %d received message. Path: %s. User ID: %d. Screen ID: %s\n", w.id, path, userID, screenIDTemp)
 
-	sess := createAWSSession(awsRegion)
+	requested, err := requestedOptions([]byte(*message.Body))
+	if err != nil {
+		return err
+	}
+	opts, err := p.grobidOptions(screenID, requested)
+	if err != nil {
+		return err
+	}
+
+	sess := createAWSSession(p.awsRegion)
 	s3Svc := s3.New(sess)
 
-	fileContent, err := downloadFileFromS3(s3Svc, s3Bucket, path)
+	p.setStage(w, jobs.StageDownload)
+
+	open, err := p.openPDFFromS3(context.Background(), s3Svc, path)
 	if err != nil {
-		log.Println("Error downloading file from S3:", err)
-		log.Printf("Bucket: %s, Key: %s\n", s3Bucket, path)
+		log.Println("Error opening file from S3:", err)
+		log.Printf("Bucket: %s, Key: %s\n", p.s3Bucket, path)
+		p.deleteOversizeUpload(s3Svc, path, err)
 		return err
 	}
+	// hashed while it is streamed, so the archive does not download the PDF again to hash it
+	pdf := parsing.NewHashingPDF(open)
 
-	CrudeGrobidResponse, err := parsing.SendPDF2Grobid(fileContent)
+	p.setStage(w, jobs.StageGrobid)
+	CrudeGrobidResponse, err := parsing.SendPDF2Grobid(context.Background(), p.grobid, pdf.Open, opts)
 	if err != nil {
 		log.Println("Error sending file to Grobid service:", err)
+		p.deleteOversizeUpload(s3Svc, path, err)
 
 		// if err contains connect: connection refused kill entire go app
 		if strings.Contains(err.Error(), "connect: connection refused") || strings.Contains(err.Error(), "server misbehaving") || strings.Contains(err.Error(), "host not found") {
@@ -253,45 +461,31 @@ func processMessage(id int, message *sqs.Message, svc *sqs.SQS, sqsURL, s3Bucket
 		return err
 	}
 
-	crossRefResponse := &parsing.TidyCrossRefResponse{}
-
-	// Cross reference data using the DOI
-	if tidyGrobidResponse.Doi != "" {
-		crossRefResponse, err = parsing.CrossRefDataDOI(tidyGrobidResponse.Doi)
-		if err != nil {
-			log.Println("Error cross referencing data using DOI:", err)
-		}
-	}
-
-	// If DOI is not available or failed, try cross-referencing using Title
-	if crossRefResponse.DOI == "" && tidyGrobidResponse.Title != "" {
-		crossRefResponse, err = parsing.CrossRefDataTitle(tidyGrobidResponse.Title)
-		if err != nil {
-			log.Println("Error cross referencing data using Title:", err)
-		}
-	}
+	p.setStage(w, jobs.StageCrossRef)
+	crossRefResponse := parsing.CrossReference(tidyGrobidResponse)
 
 	// create a PDFDTO
 	pdfDTO := parsing.CreatePDFDTO(tidyGrobidResponse, crossRefResponse)
 
 	if pdfDTO.DOI == "" {
-		s.FindDOIFromPaperRepository(pdfDTO, screenID)
+		p.store.FindDOIFromPaperRepository(pdfDTO, screenID)
 	}
 
 	// ---- Paper ----
+	p.setStage(w, jobs.StagePersist)
 	var paper store.Paper
 
 	// check if paper already exists
 	paperAlreadyExists := false
 	if pdfDTO.DOI != "" {
 		log.Println("Finding paper by DOI...")
-		paper, err = s.FindPaperByDOI(screenID, pdfDTO.DOI)
+		paper, err = p.store.FindPaperByDOI(screenID, pdfDTO.DOI)
 	} else if pdfDTO.Title != "" && pdfDTO.Abstract != "" {
 		log.Println("Finding paper by title and abstract...")
-		paper, err = s.FindPaperByTitleAndAbstract(screenID, pdfDTO.Title, pdfDTO.Abstract)
+		paper, err = p.store.FindPaperByTitleAndAbstract(screenID, pdfDTO.Title, pdfDTO.Abstract)
 	} else if pdfDTO.Title != "" {
 		log.Println("Finding paper by title...")
-		paper, err = s.FindPaperByTitle(screenID, pdfDTO.Title)
+		paper, err = p.store.FindPaperByTitle(screenID, pdfDTO.Title)
 	}
 
 	if err != nil {
@@ -300,15 +494,17 @@ func processMessage(id int, message *sqs.Message, svc *sqs.SQS, sqsURL, s3Bucket
 
 	// if paper does not exist, create it
 	if paper.ID == 0 {
-		// Get PubMed ID from DOI
-		pubMedID, err := parsing.GetPubMedIDFromDOI(pdfDTO.DOI)
-		if err != nil {
-			logging.ErrorLogger.Println(err)
-		} else {
-			pdfDTO.PubMedID = pubMedID
+		// Get PubMed ID from DOI, unless the PDF printed it
+		if pdfDTO.PubMedID == nil {
+			pubMedID, err := parsing.GetPubMedIDFromDOI(pdfDTO.DOI)
+			if err != nil {
+				logging.ErrorLogger.Println(err)
+			} else {
+				pdfDTO.PubMedID = pubMedID
+			}
 		}
 
-		paper, err = s.CreatePaper(pdfDTO, userID, screenID)
+		paper, err = p.store.CreatePaper(pdfDTO, userID, screenID)
 		if err != nil {
 			logging.ErrorLogger.Println(err)
 			return err
@@ -326,94 +522,103 @@ func processMessage(id int, message *sqs.Message, svc *sqs.SQS, sqsURL, s3Bucket
 			UserID:      userID,
 			ScreenID:    screenID,
 		}
-		err := s.SaveLog(logEntry)
+		err := p.store.SaveLog(logEntry)
 		if err != nil {
 			return err
 		}
 	}
 
 	// ---- Sections ----
-	// get sections and headings from $dto
-
-	// initialise sections off by setting the first section to the abstract
-	sections := []store.Section{
-		{
-			Header: "abstract",
-			Text:   pdfDTO.Abstract,
-		},
-	}
-	//// iterate through sections and add them to the sections array
-	for _, section := range pdfDTO.Sections {
-		if len(section.P) == 0 {
-			continue
-		}
-		section.Head = strings.ToLower(section.Head)
-
-		for _, p := range section.P {
-			//log.Printf("Section header: %s\n Section text length: %d\n", section.Head, len(p))
-			sections = append(sections, store.Section{
-				Header: section.Head,
-				Text:   p,
-			})
-		}
-	}
+	sections := buildSections(pdfDTO)
 
 	// if new section (by p), save it, else skip, give ascending order
 	// the embeddings will be created later elsewhere when the user wants to screen the full text
 	order := 0
 	if paperAlreadyExists {
-		orderTemp, _ := s.GetNextSectionOrder(paper.ID)
+		orderTemp, _ := p.store.GetNextSectionOrder(paper.ID)
 		order = int(orderTemp)
 	}
 
-	for _, section := range sections {
+	// the id of each row written is kept in sections, so subsections can point to their parent and citations to their row
+	for i, section := range sections {
 		//log.Printf("Section: %s\n", section.Header)
 		//log.Printf("Text: %s\n", section.Text)
-		_, err := s.CreateSection(paper.ID, section.Header, section.Text, order)
+		if section.ParentIndex != nil && sections[*section.ParentIndex].ID != 0 {
+			section.ParentSectionID = &sections[*section.ParentIndex].ID
+		}
+		created, err := p.store.CreateSection(paper.ID, section, order)
 		if err != nil {
 			//logging.ErrorLogger.Println(err)
 			// skip this section
 			continue
 		}
+		if createdSection, ok := created.(store.Section); ok {
+			sections[i].ID = createdSection.ID
+		}
+		metrics.SectionsWritten.Inc()
 		order++
 	}
 	log.Printf("Sections iterated: %d\n", len(sections))
 
-	key := fmt.Sprintf("rapidresearch_cache_:screen:%d:papers_processing", screenID)
+	// ---- Abstract parts, authors, references, figures and citations ----
+	// like sections, a failure here does not fail the paper. A duplicate leaves the original's alone, as it does
+	// the retained copy: reprocessing is how a paper's extraction is replaced.
+	if !paperAlreadyExists {
+		p.saveExtraction(paper.ID, screenID, pdfDTO, sections)
+	}
+
+	// archived before the upload is deleted, as the PDF is read from it
+	if p.archive != nil && paper.ID != 0 {
+		if err := p.archive.Save(context.Background(), paper.ID, pdf.Open, pdf.SHA256(), CrudeGrobidResponse.TEI); err != nil {
+			logging.ErrorLogger.Println("NON-FATAL: Error archiving TEI:", err)
+		}
+	}
+
+	key := helpers.ScreenProcessingKey(screenID)
 	// print cache value
-	val, err := cacheSvc.GetCacheValue(key)
+	val, err := p.cacheSvc.GetCacheValue(key)
 	if err != nil {
 		return err
 	}
 	log.Printf("Cache value: %s\n", val)
 
 	// decrement the cache with the screen id
-	err = cacheSvc.DecrOrDeleteCache(key)
+	err = p.cacheSvc.DecrOrDeleteCache(key)
 	if err != nil {
 		return err
 	}
 
 	if helpers.GetEnvVariable("REQUEUE_REQUESTS") == "true" {
-		_, err = svc.ChangeMessageVisibility(&sqs.ChangeMessageVisibilityInput{
-			QueueUrl:          aws.String(sqsURL),
+		_, err = p.sqsSvc.ChangeMessageVisibility(&sqs.ChangeMessageVisibilityInput{
+			QueueUrl:          aws.String(p.sqsURL),
 			ReceiptHandle:     message.ReceiptHandle,
 			VisibilityTimeout: aws.Int64(30),
 		})
 		if err != nil {
 			log.Println("Error putting message back to the queue:", err)
+		} else {
+			metrics.MessagesAcked.WithLabelValues("requeue").Inc()
 		}
 	} else {
-		_, err = svc.DeleteMessage(&sqs.DeleteMessageInput{
-			QueueUrl:      aws.String(sqsURL),
+		_, err = p.sqsSvc.DeleteMessage(&sqs.DeleteMessageInput{
+			QueueUrl:      aws.String(p.sqsURL),
 			ReceiptHandle: message.ReceiptHandle,
 		})
 		if err != nil {
 			log.Println("Error deleting message:", err)
+		} else {
+			metrics.MessagesAcked.WithLabelValues("delete").Inc()
 		}
 
-		// delete file from S3
+		// keep a copy under the paper's id so it can be reprocessed, then delete the upload. A duplicate keeps the
+		// copy retained when the paper was first processed, rather than overwriting it with another upload.
+		if !paperAlreadyExists {
+			if err := p.retain(s3Svc, path, paper.ID, CrudeGrobidResponse.TEI); err != nil {
+				log.Println("Error retaining file in S3:", err)
+			}
+		}
 		_, err = s3Svc.DeleteObject(&s3.DeleteObjectInput{
-			Bucket: aws.String(s3Bucket),
+			Bucket: aws.String(p.s3Bucket),
 			Key:    aws.String(path),
 		})
 		if err != nil {
@@ -421,6 +626,13 @@ func processMessage(id int, message *sqs.Message, svc *sqs.SQS, sqsURL, s3Bucket
 		}
 	}
 
-	log.Printf("Worker %d finished processing message\n", id)
+	p.tracker.Succeed(*message.MessageId, paper.ID, paperAlreadyExists)
+	if paperAlreadyExists {
+		metrics.DuplicatePapers.Inc()
+		metrics.MessagesProcessed.WithLabelValues(metrics.OutcomeDuplicate, "").Inc()
+	} else {
+		metrics.MessagesProcessed.WithLabelValues(metrics.OutcomeSucceeded, "").Inc()
+	}
+	log.Printf("Worker %d finished processing message\n", w.id)
 	return nil
 }
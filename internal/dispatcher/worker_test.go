@@ -1 +1,94 @@
 package dispatcher
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"simple-go-app/internal/parsing"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// test rows point to the first row of the enclosing section, through a heading without paragraphs
+func TestBuildSections(t *testing.T) {
+	pdfDTO := &parsing.PDFDTO{
+		Abstract: "Abstract.",
+		Sections: []parsing.Section{
+			{Head: "Methods", Number: "2", Depth: 1, Parent: -1},
+			{Head: "Animals", Number: "2.1", Depth: 2, Parent: 0, Paragraphs: []parsing.ParagraphRaw{{Text: "Zebrafish."}, {Text: "Larvae."}}},
+			{Head: "Statistical analysis", Number: "2.1.1", Depth: 3, Parent: 1, Paragraphs: []parsing.ParagraphRaw{{Text: "ANOVA."}}},
+			{Head: "Empty", Depth: 1, Parent: -1},
+		},
+	}
+
+	sections := buildSections(pdfDTO)
+
+	want := []struct {
+		header string
+		text   string
+		depth  int64
+		parent int
+	}{
+		{"abstract", "Abstract.", 1, -1},
+		{"methods", "Methods", 1, -1},
+		{"animals", "Zebrafish.", 2, 1},
+		{"animals", "Larvae.", 2, 1},
+		{"statistical analysis", "ANOVA.", 3, 2},
+	}
+	if len(sections) != len(want) {
+		t.Fatalf("Expected %d rows, got %+v", len(want), sections)
+	}
+	for i, w := range want {
+		section := sections[i]
+		parent := -1
+		if section.ParentIndex != nil {
+			parent = *section.ParentIndex
+		}
+		if section.Header != w.header || section.Text != w.text || section.Depth != w.depth || parent != w.parent {
+			t.Errorf("Row %d: expected %+v, got %+v (parent %d)", i, w, section, parent)
+		}
+	}
+}
+
+// test only grobid_options failing to decode is invalid options
+func TestRequestedOptions(t *testing.T) {
+	opts, err := requestedOptions([]byte(`{"s3Location": "uploads/a.pdf", "grobid_options": {"consolidate_citations": 1}}`))
+	if err != nil || opts == nil || *opts.ConsolidateCitations != 1 {
+		t.Errorf("Unexpected options %+v: %v", opts, err)
+	}
+	if opts, err := requestedOptions([]byte(`{"s3Location": "uploads/a.pdf"}`)); err != nil || opts != nil {
+		t.Errorf("Expected no options, got %+v: %v", opts, err)
+	}
+	if _, err := requestedOptions([]byte(`{"grobid_options": {"start": "one"}}`)); !errors.Is(err, errInvalidOptions) {
+		t.Errorf("Expected errInvalidOptions, got %v", err)
+	}
+	if _, err := requestedOptions([]byte(`{"s3Location": 1}`)); err != nil {
+		t.Errorf("Expected other fields to be left alone, got %v", err)
+	}
+}
+
+// test a file is read up to the limit it is downloaded with and rejected past it
+func TestDownloadFileFromS3_Limit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("a", 10)))
+	}))
+	t.Cleanup(server.Close)
+	s3Svc := s3.New(session.Must(session.NewSession(&aws.Config{
+		Region:           aws.String("eu-west-1"),
+		Endpoint:         aws.String(server.URL),
+		S3ForcePathStyle: aws.Bool(true),
+		Credentials:      credentials.NewStaticCredentials("id", "secret", ""),
+	})))
+
+	if content, err := downloadFileFromS3(s3Svc, "bucket", "retained/1.tei.xml", 10); err != nil || len(content) != 10 {
+		t.Errorf("Expected the whole file, got %d bytes and %v", len(content), err)
+	}
+	if _, err := downloadFileFromS3(s3Svc, "bucket", "retained/1.tei.xml", 9); !errors.Is(err, errTooLarge) {
+		t.Errorf("Expected errTooLarge, got %v", err)
+	}
+}
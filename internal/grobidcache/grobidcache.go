@@ -0,0 +1,231 @@
+package grobidcache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"simple-go-app/internal/archive"
+	"simple-go-app/internal/helpers"
+	"simple-go-app/internal/logging"
+	"simple-go-app/internal/metrics"
+	"simple-go-app/internal/parsing"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/uniplaces/carbon"
+	"golang.org/x/sync/singleflight"
+)
+
+// versionTTL is how long the Grobid version is trusted before asking again, so hits do not call Grobid
+const versionTTL = 5 * time.Minute
+
+// Cache holds the TEI documents Grobid returned, by Key
+type Cache interface {
+	// Get returns archive.ErrNotFound for a key that is not cached
+	Get(key string) ([]byte, error)
+	Put(key string, tei []byte) error
+}
+
+// Key identifies a Grobid result: the hex SHA-256 of the PDF, then a hash of the Grobid version and the options, so
+// upgrading Grobid or changing the options misses the cache. The order TEI coordinates are listed in does not matter.
+func Key(pdfSHA256 string, version string, opts parsing.ProcessOptions) (string, error) {
+	opts.TEICoordinates = append([]string(nil), opts.TEICoordinates...)
+	sort.Strings(opts.TEICoordinates)
+	encoded, err := json.Marshal(opts)
+	if err != nil {
+		return "", err
+	}
+	runSum := sha256.Sum256(append([]byte(version+"\n"), encoded...))
+	return pdfSHA256 + "/" + hex.EncodeToString(runSum[:8]), nil
+}
+
+// hashPDF reads the PDF through once to hash it, without holding it in memory
+func hashPDF(ctx context.Context, pdf parsing.PDFOpener) (string, error) {
+	content, err := pdf(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Grobid answers ProcessFulltextDocument from the cache when the same PDF was processed by the same Grobid version
+// with the same options, so a PDF uploaded to several screens only goes through Grobid once. Other calls go to Grobid.
+type Grobid struct {
+	parsing.Grobid
+	cache Cache
+
+	// versions shares one version request between the workers that find the version expired at the same time
+	versions  singleflight.Group
+	mu        sync.Mutex
+	version   string
+	versionAt time.Time
+}
+
+// Wrap puts a cache in front of grobid
+func Wrap(grobid parsing.Grobid, cache Cache) *Grobid {
+	return &Grobid{Grobid: grobid, cache: cache}
+}
+
+// ProcessFulltextDocument returns the cached TEI for the PDF, or processes it and caches the result
+func (g *Grobid) ProcessFulltextDocument(ctx context.Context, pdf []byte, opts parsing.ProcessOptions) ([]byte, error) {
+	return g.ProcessFulltextStream(ctx, parsing.BytesPDF(pdf), opts)
+}
+
+// ProcessFulltextStream returns the cached TEI for the PDF, or processes it and caches the result. A streamed PDF is
+// read twice on a miss, once to hash it and once to send it. Cache errors are logged and the PDF processed as on a miss.
+func (g *Grobid) ProcessFulltextStream(ctx context.Context, pdf parsing.PDFOpener, opts parsing.ProcessOptions) ([]byte, error) {
+	version, err := g.grobidVersion(ctx)
+	if err != nil {
+		logging.ErrorLogger.Println("Error getting the Grobid version for the cache:", err)
+		metrics.GrobidCacheLookups.WithLabelValues("error").Inc()
+		return g.Grobid.ProcessFulltextStream(ctx, pdf, opts)
+	}
+	pdfSHA256, err := hashPDF(ctx, pdf)
+	if err != nil {
+		return nil, err
+	}
+	key, err := Key(pdfSHA256, version, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	tei, err := g.cache.Get(key)
+	switch {
+	case err == nil:
+		metrics.GrobidCacheLookups.WithLabelValues("hit").Inc()
+		logging.InfoLogger.Println("Grobid cache hit:", key)
+		return tei, nil
+	case errors.Is(err, archive.ErrNotFound):
+		metrics.GrobidCacheLookups.WithLabelValues("miss").Inc()
+	default:
+		metrics.GrobidCacheLookups.WithLabelValues("error").Inc()
+		logging.ErrorLogger.Println("Error reading the Grobid cache:", err)
+	}
+
+	tei, err = g.Grobid.ProcessFulltextStream(ctx, pdf, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.cache.Put(key, tei); err != nil {
+		logging.ErrorLogger.Println("Error writing the Grobid cache:", err)
+	}
+	return tei, nil
+}
+
+// grobidVersion asks Grobid its version at most every versionTTL, and keeps using the last one while Grobid is down.
+// The lock is not held while Grobid is asked, so a slow answer does not hold up the workers that still have a version.
+func (g *Grobid) grobidVersion(ctx context.Context) (string, error) {
+	g.mu.Lock()
+	version, versionAt := g.version, g.versionAt
+	g.mu.Unlock()
+	if version != "" && time.Since(versionAt) < versionTTL {
+		return version, nil
+	}
+
+	fetched, err, _ := g.versions.Do("version", func() (any, error) {
+		fetched, err := g.Grobid.Version(ctx)
+		if err != nil {
+			return "", err
+		}
+		g.mu.Lock()
+		g.version, g.versionAt = fetched, time.Now()
+		g.mu.Unlock()
+		return fetched, nil
+	})
+	if err != nil {
+		if version != "" {
+			return version, nil
+		}
+		return "", err
+	}
+	return fetched.(string), nil
+}
+
+// BlobCache keeps TEI documents as blobs, in a local directory or an S3 bucket
+type BlobCache struct {
+	Blobs  archive.Blobs
+	Prefix string
+}
+
+func (c BlobCache) Get(key string) ([]byte, error) {
+	return c.Blobs.Get(c.Prefix + key + archive.KindTEI)
+}
+
+func (c BlobCache) Put(key string, tei []byte) error {
+	return c.Blobs.Put(c.Prefix+key+archive.KindTEI, bytes.NewReader(tei), "application/xml")
+}
+
+// DynamoCache indexes cached documents in a DynamoDB table and keeps the documents themselves as blobs, as TEI
+// documents often exceed the DynamoDB item size limit
+type DynamoCache struct {
+	Svc   *dynamodb.DynamoDB
+	Table string
+	Blobs BlobCache
+}
+
+func (c DynamoCache) Get(key string) ([]byte, error) {
+	result, err := c.Svc.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(c.Table),
+		Key: map[string]*dynamodb.AttributeValue{
+			"key": {S: aws.String(key)},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, archive.ErrNotFound
+	}
+	return c.Blobs.Get(key)
+}
+
+// Put writes the document before indexing it, so an indexed key always has a document
+func (c DynamoCache) Put(key string, tei []byte) error {
+	if err := c.Blobs.Put(key, tei); err != nil {
+		return err
+	}
+	_, err := c.Svc.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(c.Table),
+		Item: map[string]*dynamodb.AttributeValue{
+			"key":        {S: aws.String(key)},
+			"size":       {N: aws.String(fmt.Sprint(len(tei)))},
+			"created_at": {S: aws.String(carbon.Now().DateTimeString())},
+		},
+	})
+	return err
+}
+
+// NewFromEnv reads GROBID_CACHE_BACKEND: "dir" keeps documents in GROBID_CACHE_DIR, "s3" in GROBID_CACHE_BUCKET (the
+// uploads bucket by default) and "dynamodb" indexes them in GROBID_CACHE_TABLE with the documents in the bucket.
+// It returns nil when the backend is unset, which turns the cache off.
+func NewFromEnv(sess *session.Session, s3Svc *s3.S3, defaultBucket string) (Cache, error) {
+	prefix := helpers.GetEnvVariableDefault("GROBID_CACHE_PREFIX", "grobid-cache/")
+	bucket := archive.S3Blobs{Svc: s3Svc, Bucket: helpers.GetEnvVariableDefault("GROBID_CACHE_BUCKET", defaultBucket)}
+	switch backend := helpers.GetEnvVariableDefault("GROBID_CACHE_BACKEND", ""); backend {
+	case "":
+		return nil, nil
+	case "dir":
+		return BlobCache{Blobs: archive.DirBlobs{Dir: helpers.GetEnvVariable("GROBID_CACHE_DIR")}, Prefix: prefix}, nil
+	case "s3":
+		return BlobCache{Blobs: bucket, Prefix: prefix}, nil
+	case "dynamodb":
+		return DynamoCache{Svc: dynamodb.New(sess), Table: helpers.GetEnvVariable("GROBID_CACHE_TABLE"), Blobs: BlobCache{Blobs: bucket, Prefix: prefix}}, nil
+	default:
+		return nil, fmt.Errorf("unknown GROBID_CACHE_BACKEND %q", backend)
+	}
+}
@@ -0,0 +1,195 @@
+package grobidcache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"simple-go-app/internal/archive"
+	"simple-go-app/internal/parsing"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// fakeGrobid counts the PDFs it processes
+type fakeGrobid struct {
+	parsing.Grobid
+	version   string
+	processed int
+}
+
+func (g *fakeGrobid) Version(ctx context.Context) (string, error) {
+	return g.version, nil
+}
+
+func (g *fakeGrobid) ProcessFulltextStream(ctx context.Context, open parsing.PDFOpener, opts parsing.ProcessOptions) ([]byte, error) {
+	g.processed++
+	pdf, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer pdf.Close()
+	content, err := io.ReadAll(pdf)
+	return append([]byte("<TEI>"), content...), err
+}
+
+// test the same PDF is only processed once per Grobid version and options
+func TestGrobidCache(t *testing.T) {
+	fake := &fakeGrobid{version: "0.8.0"}
+	grobid := Wrap(fake, BlobCache{Blobs: archive.DirBlobs{Dir: t.TempDir()}, Prefix: "grobid-cache/"})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		tei, err := grobid.ProcessFulltextDocument(ctx, []byte("%PDF a"), parsing.FulltextOptions())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(tei) != "<TEI>%PDF a" {
+			t.Errorf("Unexpected TEI: %s", tei)
+		}
+	}
+	if fake.processed != 1 {
+		t.Fatalf("Expected the second call to hit the cache, processed %d", fake.processed)
+	}
+
+	if _, err := grobid.ProcessFulltextDocument(ctx, []byte("%PDF b"), parsing.FulltextOptions()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := grobid.ProcessFulltextDocument(ctx, []byte("%PDF a"), parsing.ProcessOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if fake.processed != 3 {
+		t.Errorf("Expected another PDF and other options to miss, processed %d", fake.processed)
+	}
+
+	// an upgrade is noticed once the version is asked again
+	fake.version = "0.8.1"
+	grobid.versionAt = grobid.versionAt.Add(-versionTTL)
+	if _, err := grobid.ProcessFulltextDocument(ctx, []byte("%PDF a"), parsing.FulltextOptions()); err != nil {
+		t.Fatal(err)
+	}
+	if fake.processed != 4 {
+		t.Errorf("Expected a new Grobid version to miss, processed %d", fake.processed)
+	}
+}
+
+// test keys change with the version and options but not with the options' identity or the order of coordinates
+func TestKey(t *testing.T) {
+	pdfSHA256 := "5f3c0d1b2d9b2f9b6e1c3a3d1c3b5f4e2a1b0c9d8e7f6a5b4c3d2e1f0a9b8c7d"
+	key, err := Key(pdfSHA256, "0.8.0", parsing.FulltextOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	coordinates := []string{"s", "p", "biblStruct", "figure"}
+	same, _ := Key(pdfSHA256, "0.8.0", parsing.ProcessOptions{ConsolidateHeader: 1, IncludeRawCitations: true, SegmentSentences: true, TEICoordinates: coordinates})
+	other, _ := Key(pdfSHA256, "0.8.1", parsing.FulltextOptions())
+	if key != same || key == other {
+		t.Errorf("Unexpected keys %s, %s and %s", key, same, other)
+	}
+	if len(key) != 64+1+16 {
+		t.Errorf("Unexpected key length: %s", key)
+	}
+	if coordinates[0] != "s" {
+		t.Errorf("Expected the options not to be sorted in place, got %v", coordinates)
+	}
+}
+
+// newFakeDynamo serves GetItem with item, or no item when it is nil, and records the operations called
+func newFakeDynamo(t *testing.T, item map[string]any) (*dynamodb.DynamoDB, *[]string) {
+	var operations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		operations = append(operations, strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "DynamoDB_20120810."))
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		response := map[string]any{}
+		if item != nil {
+			response["Item"] = item
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	sess := session.Must(session.NewSession(&aws.Config{
+		Region:      aws.String("eu-west-1"),
+		Endpoint:    aws.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+		MaxRetries:  aws.Int(0),
+	}))
+	return dynamodb.New(sess), &operations
+}
+
+// test an indexed key whose document is gone is a miss, and is indexed again once the document is written
+func TestDynamoCache_MissingBlob(t *testing.T) {
+	svc, operations := newFakeDynamo(t, map[string]any{"key": map[string]string{"S": "abc/def"}})
+	cache := DynamoCache{Svc: svc, Table: "grobid-cache", Blobs: BlobCache{Blobs: archive.DirBlobs{Dir: t.TempDir()}, Prefix: "grobid-cache/"}}
+
+	if _, err := cache.Get("abc/def"); !errors.Is(err, archive.ErrNotFound) {
+		t.Fatalf("Expected a miss, got %v", err)
+	}
+	if err := cache.Put("abc/def", []byte("<TEI/>")); err != nil {
+		t.Fatal(err)
+	}
+	tei, err := cache.Get("abc/def")
+	if err != nil || string(tei) != "<TEI/>" {
+		t.Errorf("Expected the written document, got %s (%v)", tei, err)
+	}
+	if want := []string{"GetItem", "PutItem", "GetItem"}; !reflect.DeepEqual(*operations, want) {
+		t.Errorf("Expected %v, got %v", want, *operations)
+	}
+}
+
+// test a key that is not indexed is a miss without reading the blob
+func TestDynamoCache_NotIndexed(t *testing.T) {
+	svc, _ := newFakeDynamo(t, nil)
+	cache := DynamoCache{Svc: svc, Table: "grobid-cache", Blobs: BlobCache{Blobs: archive.DirBlobs{Dir: t.TempDir()}}}
+
+	if _, err := cache.Get("abc/def"); !errors.Is(err, archive.ErrNotFound) {
+		t.Errorf("Expected a miss, got %v", err)
+	}
+}
+
+// test each backend is configured from the environment
+func TestNewFromEnv(t *testing.T) {
+	sess := session.Must(session.NewSession(&aws.Config{Region: aws.String("eu-west-1")}))
+	s3Svc := s3.New(sess)
+	dir := t.TempDir()
+	t.Setenv("GROBID_CACHE_DIR", dir)
+	t.Setenv("GROBID_CACHE_TABLE", "grobid-cache")
+
+	t.Setenv("GROBID_CACHE_BACKEND", "")
+	if cache, err := NewFromEnv(sess, s3Svc, "uploads"); cache != nil || err != nil {
+		t.Errorf("Expected no cache, got %v (%v)", cache, err)
+	}
+
+	t.Setenv("GROBID_CACHE_BACKEND", "dir")
+	cache, err := NewFromEnv(sess, s3Svc, "uploads")
+	if blobs, ok := cache.(BlobCache); err != nil || !ok || blobs.Blobs != (archive.DirBlobs{Dir: dir}) || blobs.Prefix != "grobid-cache/" {
+		t.Errorf("Unexpected dir cache %+v (%v)", cache, err)
+	}
+
+	t.Setenv("GROBID_CACHE_BACKEND", "s3")
+	t.Setenv("GROBID_CACHE_PREFIX", "cache/")
+	cache, err = NewFromEnv(sess, s3Svc, "uploads")
+	if blobs, ok := cache.(BlobCache); err != nil || !ok || blobs.Blobs.(archive.S3Blobs).Bucket != "uploads" || blobs.Prefix != "cache/" {
+		t.Errorf("Unexpected s3 cache %+v (%v)", cache, err)
+	}
+
+	t.Setenv("GROBID_CACHE_BACKEND", "dynamodb")
+	t.Setenv("GROBID_CACHE_BUCKET", "cache-bucket")
+	cache, err = NewFromEnv(sess, s3Svc, "uploads")
+	if dynamo, ok := cache.(DynamoCache); err != nil || !ok || dynamo.Table != "grobid-cache" || dynamo.Blobs.Blobs.(archive.S3Blobs).Bucket != "cache-bucket" {
+		t.Errorf("Unexpected dynamodb cache %+v (%v)", cache, err)
+	}
+
+	t.Setenv("GROBID_CACHE_BACKEND", "redis")
+	if _, err := NewFromEnv(sess, s3Svc, "uploads"); err == nil {
+		t.Errorf("Expected an unknown backend to fail")
+	}
+}
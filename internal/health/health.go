@@ -0,0 +1,135 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sqs"
+)
+
+const (
+	StatusOK    = "ok"
+	StatusError = "error"
+)
+
+// CheckFunc reports whether a dependency is usable, it should give up when ctx is done
+type CheckFunc func(ctx context.Context) error
+
+// Result is the outcome of the latest run of a check, along with the last time it failed
+type Result struct {
+	Status      string     `json:"status"`
+	LatencyMS   float64    `json:"latency_ms"`
+	Error       string     `json:"error,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+	CheckedAt   time.Time  `json:"checked_at"`
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Checker runs a set of dependency checks concurrently and remembers their last errors
+type Checker struct {
+	timeout time.Duration
+	checks  []check
+
+	mu      sync.Mutex
+	results map[string]Result
+}
+
+// New creates a Checker that gives each check at most timeout to complete
+func New(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout: timeout,
+		results: make(map[string]Result),
+	}
+}
+
+// Add registers a named check
+func (c *Checker) Add(name string, fn CheckFunc) {
+	c.checks = append(c.checks, check{name: name, fn: fn})
+}
+
+// Run executes every check and returns the results keyed by name, and whether all of them passed
+func (c *Checker) Run(ctx context.Context) (map[string]Result, bool) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, chk := range c.checks {
+		wg.Add(1)
+		go func(chk check) {
+			defer wg.Done()
+			start := time.Now()
+			err := chk.fn(ctx)
+			c.record(chk.name, start, err)
+		}(chk)
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	results := make(map[string]Result, len(c.checks))
+	healthy := true
+	for _, chk := range c.checks {
+		result := c.results[chk.name]
+		if result.Status != StatusOK {
+			healthy = false
+		}
+		results[chk.name] = result
+	}
+	return results, healthy
+}
+
+func (c *Checker) record(name string, start time.Time, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := c.results[name]
+	result.CheckedAt = time.Now()
+	result.LatencyMS = float64(result.CheckedAt.Sub(start).Microseconds()) / 1000
+	result.Status = StatusOK
+	result.Error = ""
+	if err != nil {
+		result.Status = StatusError
+		result.Error = err.Error()
+		failedAt := result.CheckedAt
+		result.LastError = err.Error()
+		result.LastErrorAt = &failedAt
+	}
+	c.results[name] = result
+}
+
+// MySQL checks the database answers a ping
+func MySQL(db *sql.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// SQSQueue checks the requests queue can be read
+func SQSQueue(svc *sqs.SQS, queueURL string) CheckFunc {
+	return func(ctx context.Context) error {
+		_, err := svc.GetQueueAttributesWithContext(ctx, &sqs.GetQueueAttributesInput{
+			QueueUrl:       aws.String(queueURL),
+			AttributeNames: []*string{aws.String(sqs.QueueAttributeNameApproximateNumberOfMessages)},
+		})
+		return err
+	}
+}
+
+// S3Bucket checks the upload bucket exists and is accessible
+func S3Bucket(svc *s3.S3, bucket string) CheckFunc {
+	return func(ctx context.Context) error {
+		_, err := svc.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+			Bucket: aws.String(bucket),
+		})
+		return err
+	}
+}
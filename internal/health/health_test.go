@@ -0,0 +1,41 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// test a failing check keeps its last error after it recovers
+func TestChecker_Run(t *testing.T) {
+	failing := true
+	checker := New(time.Second)
+	checker.Add("grobid", func(ctx context.Context) error {
+		if failing {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	checker.Add("mysql", func(ctx context.Context) error { return nil })
+
+	results, healthy := checker.Run(context.Background())
+	if healthy {
+		t.Errorf("Expected checker to be unhealthy")
+	}
+	if results["grobid"].Status != StatusError || results["grobid"].Error != "connection refused" {
+		t.Errorf("Unexpected grobid result: %+v", results["grobid"])
+	}
+	if results["mysql"].Status != StatusOK {
+		t.Errorf("Unexpected mysql result: %+v", results["mysql"])
+	}
+
+	failing = false
+	results, healthy = checker.Run(context.Background())
+	if !healthy {
+		t.Errorf("Expected checker to be healthy")
+	}
+	if results["grobid"].Error != "" || results["grobid"].LastError != "connection refused" || results["grobid"].LastErrorAt == nil {
+		t.Errorf("Unexpected recovered grobid result: %+v", results["grobid"])
+	}
+}
@@ -1,6 +1,9 @@
 package helpers
 
 import (
+	"context"
+	"fmt"
+	"strconv"
 	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -15,6 +18,12 @@ type CacheHelper struct {
 	mu        sync.Mutex
 }
 
+// ScreenProcessingKey returns the cache key holding the number of papers still processing for a screen.
+// The key is shared with the main app, which increments it when a PDF is uploaded.
+func ScreenProcessingKey(screenID int64) string {
+	return fmt.Sprintf("rapidresearch_cache_:screen:%d:papers_processing", screenID)
+}
+
 // NewCacheHelper creates a new CacheHelper instance with DynamoDB as the backend.
 func NewCacheHelper(sess *session.Session, tableName string) (*CacheHelper, error) {
 	svc := dynamodb.New(sess)
@@ -148,3 +157,20 @@ func (c *CacheHelper) GetCacheValue(key string) (string, error) {
 
 	return "", nil
 }
+
+// GetCacheCount returns the value associated with a key as an integer, treating a missing key as zero.
+func (c *CacheHelper) GetCacheCount(key string) (int64, error) {
+	val, err := c.GetCacheValue(key)
+	if err != nil || val == "" {
+		return 0, err
+	}
+	return strconv.ParseInt(val, 10, 64)
+}
+
+// Ping checks the cache table exists and is reachable.
+func (c *CacheHelper) Ping(ctx context.Context) error {
+	_, err := c.svc.DescribeTableWithContext(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(c.tableName),
+	})
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/joho/godotenv"
 	"log"
 	"os"
+	"strconv"
 )
 
 func LoadEnv() {
@@ -22,3 +23,21 @@ func GetEnvVariable(key string) string {
 	}
 	return value
 }
+
+// GetEnvVariableDefault returns the value of an optional environment variable, or fallback when it is unset
+func GetEnvVariableDefault(key, fallback string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// GetEnvIntDefault returns an optional environment variable parsed as an int, or fallback when it is unset or invalid
+func GetEnvIntDefault(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,364 @@
+package jobs
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Status is the lifecycle state of a job
+type Status string
+
+const (
+	StatusQueued     Status = "queued"
+	StatusInProgress Status = "in_progress"
+	StatusSucceeded  Status = "succeeded"
+	StatusFailed     Status = "failed"
+	StatusDuplicate  Status = "duplicate"
+)
+
+// Stages a worker moves a job through while processing it
+const (
+	StageQueued   = "queued"
+	StageDownload = "s3_download"
+	StageGrobid   = "grobid"
+	StageCrossRef = "crossref"
+	StagePersist  = "db_write"
+	StageDone     = "done"
+)
+
+// Job is a single PDF processing request, identified by the id of the SQS message that carried it
+type Job struct {
+	ID         string     `json:"id"`
+	ScreenID   int64      `json:"screen_id"`
+	UserID     int64      `json:"user_id"`
+	S3Location string     `json:"s3_location"`
+	Status     Status     `json:"status"`
+	Stage      string     `json:"stage"`
+	PaperID    int64      `json:"paper_id,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	QueuedAt   time.Time  `json:"queued_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// Progress summarises the jobs of a single screen
+type Progress struct {
+	ScreenID   int64    `json:"screen_id"`
+	Queued     int64    `json:"queued"`
+	InProgress int64    `json:"in_progress"`
+	Succeeded  int64    `json:"succeeded"`
+	Failed     int64    `json:"failed"`
+	Duplicate  int64    `json:"duplicate"`
+	Remaining  int64    `json:"remaining"`
+	ETASeconds *float64 `json:"eta_seconds"`
+}
+
+// screenCounts keeps the per screen totals so they survive pruning of finished jobs
+type screenCounts struct {
+	queued     int64
+	inProgress int64
+	succeeded  int64
+	failed     int64
+	duplicate  int64
+	// changedAt lets a screen without pending jobs be forgotten like finished jobs
+	changedAt time.Time
+}
+
+// Tracker records the state of jobs seen by this instance and notifies subscribers when they change
+type Tracker struct {
+	mu          sync.RWMutex
+	jobs        map[string]*Job
+	screens     map[int64]*screenCounts
+	subscribers map[int64]map[chan struct{}]struct{}
+	avgDuration time.Duration
+	retention   time.Duration
+}
+
+// NewTracker creates a Tracker that forgets finished jobs, and screens whose jobs have all finished, once they are
+// older than retention
+func NewTracker(retention time.Duration) *Tracker {
+	return &Tracker{
+		jobs:        make(map[string]*Job),
+		screens:     make(map[int64]*screenCounts),
+		subscribers: make(map[int64]map[chan struct{}]struct{}),
+		retention:   retention,
+	}
+}
+
+// Enqueue registers a job for a message that has been received but not yet picked up by a worker.
+// The message body is inspected for the user, screen and s3 location; unknown fields are ignored.
+func (t *Tracker) Enqueue(id, body string) {
+	var msgData map[string]interface{}
+	_ = json.Unmarshal([]byte(body), &msgData)
+
+	job := &Job{
+		ID:       id,
+		Status:   StatusQueued,
+		Stage:    StageQueued,
+		QueuedAt: time.Now(),
+	}
+	job.UserID = intField(msgData, "user_id")
+	job.ScreenID = intField(msgData, "screen_id")
+	if location, ok := msgData["s3Location"].(string); ok {
+		job.S3Location = location
+	}
+
+	t.mu.Lock()
+	t.prune()
+	if existing, ok := t.jobs[id]; ok {
+		// SQS redelivered a message we already know about, start it again from the queue
+		t.counts(existing.ScreenID).remove(existing.Status)
+	}
+	t.jobs[id] = job
+	t.counts(job.ScreenID).queued++
+	t.mu.Unlock()
+
+	t.notify(job.ScreenID)
+}
+
+// Start marks a job as being processed by a worker
+func (t *Tracker) Start(id string) {
+	t.update(id, func(job *Job) {
+		now := time.Now()
+		job.StartedAt = &now
+		t.transition(job, StatusInProgress)
+	})
+}
+
+// SetStage records the pipeline stage a job has reached
+func (t *Tracker) SetStage(id, stage string) {
+	t.update(id, func(job *Job) {
+		job.Stage = stage
+	})
+}
+
+// Succeed marks a job as finished, either as a new paper or as a duplicate of an existing one
+func (t *Tracker) Succeed(id string, paperID int64, duplicate bool) {
+	t.update(id, func(job *Job) {
+		job.PaperID = paperID
+		if duplicate {
+			t.finish(job, StatusDuplicate)
+		} else {
+			t.finish(job, StatusSucceeded)
+		}
+	})
+}
+
+// Fail marks a job as failed with the given error
+func (t *Tracker) Fail(id string, err error) {
+	t.update(id, func(job *Job) {
+		if err != nil {
+			job.Error = err.Error()
+		}
+		t.finish(job, StatusFailed)
+	})
+}
+
+// Release forgets a queued job whose message was handed back to SQS, so it no longer counts as queued here.
+// Another instance, or this one later, enqueues it again when it receives the message.
+func (t *Tracker) Release(id string) {
+	t.mu.Lock()
+	job, ok := t.jobs[id]
+	if !ok || job.Status != StatusQueued {
+		t.mu.Unlock()
+		return
+	}
+	t.counts(job.ScreenID).remove(job.Status)
+	delete(t.jobs, id)
+	screenID := job.ScreenID
+	t.mu.Unlock()
+
+	t.notify(screenID)
+}
+
+// Get returns a copy of the job with the given id
+func (t *Tracker) Get(id string) (Job, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// pruned on reads too, so an instance that stops receiving messages still forgets its finished jobs
+	t.prune()
+	job, ok := t.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+// Progress returns the progress of a screen. remaining is the number of papers the main app still
+// considers processing for the screen, and workers the number of workers sharing the queue; both are
+// used to estimate the time left.
+func (t *Tracker) Progress(screenID, remaining int64, workers int) Progress {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.prune()
+
+	progress := Progress{ScreenID: screenID, Remaining: remaining}
+	if counts, ok := t.screens[screenID]; ok {
+		progress.Queued = counts.queued
+		progress.InProgress = counts.inProgress
+		progress.Succeeded = counts.succeeded
+		progress.Failed = counts.failed
+		progress.Duplicate = counts.duplicate
+	}
+
+	// the shared counter also includes messages other instances or SQS are still holding
+	if queued := remaining - progress.InProgress; queued > progress.Queued {
+		progress.Queued = queued
+	}
+
+	if remaining > 0 && t.avgDuration > 0 {
+		if workers < 1 {
+			workers = 1
+		}
+		eta := (t.avgDuration.Seconds() * float64(remaining)) / float64(workers)
+		progress.ETASeconds = &eta
+	}
+
+	return progress
+}
+
+// Subscribe returns a channel that receives a value whenever a job of the screen changes.
+// The returned function must be called to release the subscription.
+func (t *Tracker) Subscribe(screenID int64) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	t.mu.Lock()
+	if t.subscribers[screenID] == nil {
+		t.subscribers[screenID] = make(map[chan struct{}]struct{})
+	}
+	t.subscribers[screenID][ch] = struct{}{}
+	t.mu.Unlock()
+
+	return ch, func() {
+		t.mu.Lock()
+		delete(t.subscribers[screenID], ch)
+		if len(t.subscribers[screenID]) == 0 {
+			delete(t.subscribers, screenID)
+		}
+		t.mu.Unlock()
+	}
+}
+
+func (t *Tracker) update(id string, fn func(job *Job)) {
+	t.mu.Lock()
+	job, ok := t.jobs[id]
+	if !ok {
+		t.mu.Unlock()
+		return
+	}
+	fn(job)
+	screenID := job.ScreenID
+	t.mu.Unlock()
+
+	t.notify(screenID)
+}
+
+// transition moves a job between statuses, keeping the screen counts in step. Callers hold t.mu.
+func (t *Tracker) transition(job *Job, status Status) {
+	counts := t.counts(job.ScreenID)
+	counts.remove(job.Status)
+	counts.add(status)
+	job.Status = status
+}
+
+// finish moves a job into a terminal status and folds its duration into the running average. Callers hold t.mu.
+func (t *Tracker) finish(job *Job, status Status) {
+	now := time.Now()
+	job.FinishedAt = &now
+	job.Stage = StageDone
+	t.transition(job, status)
+
+	if job.StartedAt == nil {
+		return
+	}
+	duration := now.Sub(*job.StartedAt)
+	if t.avgDuration == 0 {
+		t.avgDuration = duration
+	} else {
+		// exponential moving average so the estimate follows changes in Grobid load
+		t.avgDuration = (t.avgDuration*4 + duration) / 5
+	}
+}
+
+// counts returns the counts of a screen for a change. Callers hold t.mu.
+func (t *Tracker) counts(screenID int64) *screenCounts {
+	counts, ok := t.screens[screenID]
+	if !ok {
+		counts = &screenCounts{}
+		t.screens[screenID] = counts
+	}
+	counts.changedAt = time.Now()
+	return counts
+}
+
+// prune forgets finished jobs, and screens without queued or in progress jobs, that have not changed for the
+// retention period. Callers hold t.mu.
+func (t *Tracker) prune() {
+	cutoff := time.Now().Add(-t.retention)
+	for id, job := range t.jobs {
+		if job.FinishedAt != nil && job.FinishedAt.Before(cutoff) {
+			delete(t.jobs, id)
+		}
+	}
+	for screenID, counts := range t.screens {
+		if counts.queued == 0 && counts.inProgress == 0 && counts.changedAt.Before(cutoff) {
+			delete(t.screens, screenID)
+		}
+	}
+}
+
+func (t *Tracker) notify(screenID int64) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for ch := range t.subscribers[screenID] {
+		// subscribers only need to know something changed, so never block on a slow reader
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (c *screenCounts) add(status Status) {
+	switch status {
+	case StatusQueued:
+		c.queued++
+	case StatusInProgress:
+		c.inProgress++
+	case StatusSucceeded:
+		c.succeeded++
+	case StatusFailed:
+		c.failed++
+	case StatusDuplicate:
+		c.duplicate++
+	}
+}
+
+func (c *screenCounts) remove(status Status) {
+	switch status {
+	case StatusQueued:
+		c.queued--
+	case StatusInProgress:
+		c.inProgress--
+	case StatusSucceeded:
+		c.succeeded--
+	case StatusFailed:
+		c.failed--
+	case StatusDuplicate:
+		c.duplicate--
+	}
+}
+
+// intField reads an id from a message, which the main app sends as a string
+func intField(msgData map[string]interface{}, key string) int64 {
+	switch value := msgData[key].(type) {
+	case string:
+		id, _ := strconv.ParseInt(value, 10, 64)
+		return id
+	case float64:
+		return int64(value)
+	}
+	return 0
+}
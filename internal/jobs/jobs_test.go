@@ -0,0 +1,97 @@
+package jobs
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// test a screen's counts as its jobs move through the pipeline
+func TestTracker_Progress(t *testing.T) {
+	tracker := NewTracker(time.Hour)
+
+	body := `{"s3Location": "uploads/a.pdf", "user_id": "3", "screen_id": "7"}`
+	tracker.Enqueue("a", body)
+	tracker.Enqueue("b", body)
+	tracker.Enqueue("c", body)
+
+	updates, unsubscribe := tracker.Subscribe(7)
+	defer unsubscribe()
+
+	tracker.Start("a")
+	tracker.SetStage("a", StageGrobid)
+	select {
+	case <-updates:
+	default:
+		t.Errorf("Subscriber was not notified")
+	}
+
+	job, ok := tracker.Get("a")
+	if !ok {
+		t.Fatalf("Job not found")
+	}
+	if job.Status != StatusInProgress || job.Stage != StageGrobid || job.ScreenID != 7 || job.UserID != 3 {
+		t.Errorf("Unexpected job: %+v", job)
+	}
+
+	tracker.Succeed("a", 42, false)
+	tracker.Start("b")
+	tracker.Succeed("b", 42, true)
+	tracker.Start("c")
+	tracker.Fail("c", errors.New("grobid service returned non-OK status"))
+
+	progress := tracker.Progress(7, 4, 2)
+	if progress.Succeeded != 1 || progress.Duplicate != 1 || progress.Failed != 1 || progress.InProgress != 0 {
+		t.Errorf("Unexpected progress: %+v", progress)
+	}
+	// the main app still counts four papers, none of which this instance has seen yet
+	if progress.Queued != 4 {
+		t.Errorf("Expected 4 queued, got %d", progress.Queued)
+	}
+	if progress.ETASeconds == nil {
+		t.Errorf("Expected an ETA once jobs have finished")
+	}
+
+	job, _ = tracker.Get("c")
+	if job.Status != StatusFailed || job.Error == "" || job.FinishedAt == nil {
+		t.Errorf("Unexpected failed job: %+v", job)
+	}
+}
+
+// test screens whose jobs have all finished are forgotten after the retention period, and busy screens are kept
+func TestTracker_PruneScreens(t *testing.T) {
+	tracker := NewTracker(10 * time.Millisecond)
+	tracker.Enqueue("a", `{"s3Location": "uploads/a.pdf", "user_id": "3", "screen_id": "7"}`)
+	tracker.Start("a")
+	tracker.Succeed("a", 42, false)
+	tracker.Enqueue("b", `{"s3Location": "uploads/b.pdf", "user_id": "3", "screen_id": "8"}`)
+
+	time.Sleep(20 * time.Millisecond)
+	tracker.Enqueue("c", `{"s3Location": "uploads/c.pdf", "user_id": "3", "screen_id": "9"}`)
+
+	if _, ok := tracker.screens[7]; ok {
+		t.Errorf("Expected finished screen 7 to be pruned")
+	}
+	if _, ok := tracker.Get("a"); ok {
+		t.Errorf("Expected finished job to be pruned")
+	}
+	if progress := tracker.Progress(8, 0, 1); progress.Queued != 1 {
+		t.Errorf("Expected screen 8 to keep its queued job, got %+v", progress)
+	}
+}
+
+// test finished jobs are forgotten by reads alone, without another job being enqueued
+func TestTracker_PruneOnRead(t *testing.T) {
+	tracker := NewTracker(10 * time.Millisecond)
+	tracker.Enqueue("a", `{"s3Location": "uploads/a.pdf", "user_id": "3", "screen_id": "7"}`)
+	tracker.Start("a")
+	tracker.Fail("a", nil)
+
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := tracker.Get("a"); ok {
+		t.Errorf("Expected the finished job to be pruned")
+	}
+	if progress := tracker.Progress(7, 0, 1); progress.Failed != 0 {
+		t.Errorf("Expected the finished screen to be pruned, got %+v", progress)
+	}
+}
@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "grobid_sidecar"
+
+// Outcomes of a processed message
+const (
+	OutcomeSucceeded = "succeeded"
+	OutcomeDuplicate = "duplicate"
+	OutcomeFailed    = "failed"
+)
+
+var (
+	MessagesReceived = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_received_total",
+		Help:      "Messages received from the requests queue.",
+	})
+
+	MessagesAcked = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_acked_total",
+		Help:      "Messages removed from the queue or put back for requeueing after processing, by action.",
+	}, []string{"action"})
+
+	MessagesProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_processed_total",
+		Help:      "Messages processed by the workers, by outcome and the class of error a message failed with, such as timeout, grobid_5xx, s3, db or parse.",
+	}, []string{"outcome", "error_class"})
+
+	StageDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "stage_duration_seconds",
+		Help:      "Time spent in each stage of processing a message.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	}, []string{"stage"})
+
+	GrobidResponses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grobid_responses_total",
+		Help:      "Responses from Grobid by endpoint and HTTP status code, code is \"error\" when no response was received.",
+	}, []string{"endpoint", "code"})
+
+	GrobidCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grobid_cache_lookups_total",
+		Help:      "Lookups of the Grobid result cache by result: hit, miss or error.",
+	}, []string{"result"})
+
+	QueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "queue_depth",
+		Help:      "Approximate number of messages in the requests queue, by visibility.",
+	}, []string{"visibility"})
+
+	BufferedMessages = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "buffered_messages",
+		Help:      "Messages received by this instance that no worker has started yet.",
+	})
+
+	Workers = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "workers",
+		Help:      "Worker goroutines currently running.",
+	})
+
+	WorkersBusy = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "workers_busy",
+		Help:      "Workers currently processing a message.",
+	})
+
+	DuplicatePapers = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "duplicate_papers_total",
+		Help:      "Processed PDFs that matched a paper already in the screen.",
+	})
+
+	SectionsWritten = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sections_written_total",
+		Help:      "Sections saved to the database.",
+	})
+)
+
+// ObserveGrobidResponse counts a Grobid response, or a failed request when err is not nil
+func ObserveGrobidResponse(endpoint string, statusCode int, err error) {
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(statusCode)
+	}
+	GrobidResponses.WithLabelValues(endpoint, code).Inc()
+}
+
+// WatchQueueDepth polls the approximate size of the queue every interval. It never returns.
+func WatchQueueDepth(svc *sqs.SQS, queueURL string, interval time.Duration) {
+	for {
+		result, err := svc.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+			QueueUrl: aws.String(queueURL),
+			AttributeNames: []*string{
+				aws.String(sqs.QueueAttributeNameApproximateNumberOfMessages),
+				aws.String(sqs.QueueAttributeNameApproximateNumberOfMessagesNotVisible),
+				aws.String(sqs.QueueAttributeNameApproximateNumberOfMessagesDelayed),
+			},
+		})
+		if err != nil {
+			log.Println("Error reading queue attributes:", err)
+		} else {
+			setQueueDepth("visible", result.Attributes[sqs.QueueAttributeNameApproximateNumberOfMessages])
+			setQueueDepth("not_visible", result.Attributes[sqs.QueueAttributeNameApproximateNumberOfMessagesNotVisible])
+			setQueueDepth("delayed", result.Attributes[sqs.QueueAttributeNameApproximateNumberOfMessagesDelayed])
+		}
+		time.Sleep(interval)
+	}
+}
+
+func setQueueDepth(visibility string, value *string) {
+	if value == nil {
+		return
+	}
+	depth, err := strconv.ParseFloat(*value, 64)
+	if err != nil {
+		return
+	}
+	QueueDepth.WithLabelValues(visibility).Set(depth)
+}
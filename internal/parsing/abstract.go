@@ -0,0 +1,54 @@
+package parsing
+
+import (
+	"regexp"
+	"strings"
+)
+
+// AbstractRaw is the abstract of the header, a div per part for structured abstracts
+type AbstractRaw struct {
+	Divs []SectionRaw   `xml:"div"`
+	P    []ParagraphRaw `xml:"p"`
+}
+
+// AbstractPart is a labelled part of a structured abstract, such as Background or Methods.
+// Unstructured abstracts are a single part without a label.
+type AbstractPart struct {
+	Label string `json:"label"`
+	Text  string `json:"text"`
+}
+
+// spaceBeforePunctuation is left where a citation marker stood between a word and the punctuation after it
+var spaceBeforePunctuation = regexp.MustCompile(`\s+([.,;:])`)
+
+// TidyAbstract reads every div and paragraph of the abstract in order, skipping parts without text
+func TidyAbstract(raw AbstractRaw) []AbstractPart {
+	var parts []AbstractPart
+	add := func(label string, paragraphs []ParagraphRaw) {
+		var texts []string
+		for _, p := range paragraphs {
+			if text := spaceBeforePunctuation.ReplaceAllString(collapseSpace(p.Text), "$1"); text != "" {
+				texts = append(texts, text)
+			}
+		}
+		if len(texts) > 0 {
+			parts = append(parts, AbstractPart{Label: strings.TrimSuffix(collapseSpace(label), ":"), Text: strings.Join(texts, "\n")})
+		}
+	}
+
+	add("", raw.P)
+	for _, div := range raw.Divs {
+		add(div.Head.Text, div.P)
+	}
+	return parts
+}
+
+// FlattenAbstract joins the parts into the text of the abstract column. Labels are left to the abstract_parts table,
+// so the column holds the same text whether or not the abstract is structured and papers are still matched on it.
+func FlattenAbstract(parts []AbstractPart) string {
+	var texts []string
+	for _, part := range parts {
+		texts = append(texts, part.Text)
+	}
+	return strings.Join(texts, "\n\n")
+}
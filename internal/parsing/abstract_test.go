@@ -0,0 +1,53 @@
+package parsing
+
+import (
+	"reflect"
+	"testing"
+)
+
+const abstractTEI = `<?xml version="1.0" encoding="UTF-8"?>
+<TEI xmlns="http://www.tei-c.org/ns/1.0">
+	<teiHeader>
+		<profileDesc>
+			<abstract>
+				<div><head>Background:</head><p><s>Zebrafish are a model.</s><s>They are small.</s></p></div>
+				<div><head>Methods</head><p>Larvae were exposed.</p><p>Doses varied <ref type="bibr" target="#b0">[1]</ref>.</p></div>
+				<div><head>Empty</head></div>
+				<div><head>Conclusions</head><p>It   works.</p></div>
+			</abstract>
+		</profileDesc>
+	</teiHeader>
+</TEI>`
+
+// test every part of a structured abstract is kept with its label and flattened in order
+func TestTidyAbstract(t *testing.T) {
+	crude, err := ParseGrobidResponse([]byte(abstractTEI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := TidyAbstract(crude.Abstract)
+	want := []AbstractPart{
+		{Label: "Background", Text: "Zebrafish are a model. They are small."},
+		{Label: "Methods", Text: "Larvae were exposed.\nDoses varied."},
+		{Label: "Conclusions", Text: "It works."},
+	}
+	if !reflect.DeepEqual(parts, want) {
+		t.Fatalf("Unexpected parts: %+v", parts)
+	}
+	flattened := "Zebrafish are a model. They are small.\n\nLarvae were exposed.\nDoses varied.\n\nIt works."
+	if text := FlattenAbstract(parts); text != flattened {
+		t.Errorf("Unexpected abstract: %q", text)
+	}
+}
+
+// test an abstract without divs is a single part without a label
+func TestTidyAbstractUnstructured(t *testing.T) {
+	parts := TidyAbstract(AbstractRaw{P: []ParagraphRaw{{Text: " One paragraph. "}}})
+	if !reflect.DeepEqual(parts, []AbstractPart{{Text: "One paragraph."}}) {
+		t.Fatalf("Unexpected parts: %+v", parts)
+	}
+	if text := FlattenAbstract(parts); text != "One paragraph." {
+		t.Errorf("Unexpected abstract: %q", text)
+	}
+}
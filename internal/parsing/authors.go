@@ -0,0 +1,95 @@
+package parsing
+
+import (
+	"regexp"
+	"strings"
+)
+
+type AffiliationRaw struct {
+	OrgNames []OrgNameRaw `xml:"orgName"`
+	Country  string       `xml:"address>country"`
+}
+
+type OrgNameRaw struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+// Author is an author of a paper as Grobid read it from the header
+type Author struct {
+	Forename string `json:"forename"`
+	Surname  string `json:"surname"`
+	Email    string `json:"email"`
+	ORCID    string `json:"orcid"`
+	// Corresponding is set from the corresp role Grobid gives the author the paper marks as corresponding
+	Corresponding bool          `json:"corresponding"`
+	Affiliations  []Affiliation `json:"affiliations"`
+}
+
+type Affiliation struct {
+	Department  string `json:"department"`
+	Institution string `json:"institution"`
+	Country     string `json:"country"`
+}
+
+// TidyAuthors turns the header authors into authors, skipping the entries Grobid creates for orphan affiliations
+func TidyAuthors(raw []AuthorsRaw) []Author {
+	var authors []Author
+	for _, entry := range raw {
+		author := Author{
+			Forename:      collapseSpace(strings.Join(entry.PersName.Forenames, " ")),
+			Surname:       collapseSpace(entry.PersName.Surname),
+			Email:         strings.TrimSpace(entry.Email),
+			ORCID:         orcid(entry.IDNOs),
+			Corresponding: entry.Role == "corresp",
+		}
+		if author.Forename == "" && author.Surname == "" {
+			continue
+		}
+		for _, affiliation := range entry.Affiliations {
+			if a := affiliation.tidy(); a != (Affiliation{}) {
+				author.Affiliations = append(author.Affiliations, a)
+			}
+		}
+		authors = append(authors, author)
+	}
+	return authors
+}
+
+// tidy keeps the first department and institution, laboratories are folded into the department when it is missing
+func (a AffiliationRaw) tidy() Affiliation {
+	affiliation := Affiliation{Country: collapseSpace(a.Country)}
+	var laboratory string
+	for _, org := range a.OrgNames {
+		name := collapseSpace(org.Text)
+		switch {
+		case org.Type == "department" && affiliation.Department == "":
+			affiliation.Department = name
+		case org.Type == "institution" && affiliation.Institution == "":
+			affiliation.Institution = name
+		case org.Type == "laboratory" && laboratory == "":
+			laboratory = name
+		}
+	}
+	if affiliation.Department == "" {
+		affiliation.Department = laboratory
+	}
+	return affiliation
+}
+
+var orcidPattern = regexp.MustCompile(`^\d{4}-\d{4}-\d{4}-\d{3}[\dX]$`)
+
+// orcid returns the bare ORCID, Grobid sometimes keeps the orcid.org URL. Anything that is not an ORCID once the URL
+// is removed, such as text Grobid misread as one, is dropped.
+func orcid(idnos []BiblIdnoRaw) string {
+	for _, idno := range idnos {
+		if strings.EqualFold(idno.Type, "ORCID") {
+			value := strings.ToUpper(strings.TrimSpace(idno.Value))
+			value = value[strings.LastIndex(value, "/")+1:]
+			if orcidPattern.MatchString(value) {
+				return value
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+package parsing
+
+import (
+	"reflect"
+	"testing"
+)
+
+const headerTEI = `<?xml version="1.0" encoding="UTF-8"?>
+<TEI xmlns="http://www.tei-c.org/ns/1.0">
+	<teiHeader>
+		<fileDesc>
+			<titleStmt><title>A paper</title></titleStmt>
+			<sourceDesc>
+				<biblStruct>
+					<analytic>
+						<author role="corresp">
+							<persName><forename type="first">Ana</forename><forename type="middle">M</forename><surname>Silva</surname></persName>
+							<email>ana.silva@example.org</email>
+							<idno type="ORCID">https://orcid.org/0000-0002-1825-0097</idno>
+							<affiliation key="aff0">
+								<orgName type="department">Department of Biology</orgName>
+								<orgName type="institution">Universidade Federal</orgName>
+								<address><settlement>Porto Alegre</settlement><country key="BR">Brazil</country></address>
+							</affiliation>
+						</author>
+						<author>
+							<persName><forename type="first">João</forename><surname>Costa</surname></persName>
+							<affiliation key="aff1">
+								<orgName type="laboratory">Zebrafish Lab</orgName>
+								<orgName type="institution">Instituto</orgName>
+							</affiliation>
+						</author>
+						<author>
+							<affiliation key="aff2"><orgName type="institution">Orphan</orgName></affiliation>
+						</author>
+					</analytic>
+					<idno type="DOI">10.1000/paper</idno>
+				</biblStruct>
+			</sourceDesc>
+		</fileDesc>
+	</teiHeader>
+</TEI>`
+
+// test authors are read from the header with their affiliations
+func TestTidyAuthors(t *testing.T) {
+	crude, err := ParseGrobidResponse([]byte(headerTEI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Author{
+		{
+			Forename:      "Ana M",
+			Surname:       "Silva",
+			Email:         "ana.silva@example.org",
+			ORCID:         "0000-0002-1825-0097",
+			Corresponding: true,
+			Affiliations:  []Affiliation{{Department: "Department of Biology", Institution: "Universidade Federal", Country: "Brazil"}},
+		},
+		{
+			Forename:     "João",
+			Surname:      "Costa",
+			Affiliations: []Affiliation{{Department: "Zebrafish Lab", Institution: "Instituto"}},
+		},
+	}
+	if authors := TidyAuthors(crude.Authors); !reflect.DeepEqual(authors, want) {
+		t.Errorf("Unexpected authors: %+v", authors)
+	}
+}
+
+// test ORCIDs are read bare and anything else is dropped
+func TestORCID(t *testing.T) {
+	tests := map[string]string{
+		"https://orcid.org/0000-0002-1825-0097": "0000-0002-1825-0097",
+		"0000-0002-1694-233x":                   "0000-0002-1694-233X",
+		"0000-0002-1825":                        "",
+		"ORCID 0000-0002-1825-0097 (Ana Silva)": "",
+	}
+	for value, want := range tests {
+		if got := orcid([]BiblIdnoRaw{{Type: "ORCID", Value: value}}); got != want {
+			t.Errorf("%q: expected %q, got %q", value, want, got)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package parsing
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Box is an area of a PDF page in PDF points from the top left corner, as Grobid reports it in coords attributes
+type Box struct {
+	Page   int     `json:"page"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// ParseCoords reads a coords attribute, "page,x,y,width,height" boxes separated by semicolons, skipping malformed boxes
+func ParseCoords(coords string) []Box {
+	var boxes []Box
+	for _, part := range strings.Split(coords, ";") {
+		fields := strings.Split(strings.TrimSpace(part), ",")
+		if len(fields) != 5 {
+			continue
+		}
+		page, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		box := Box{Page: page}
+		values := []*float64{&box.X, &box.Y, &box.Width, &box.Height}
+		valid := true
+		for i, value := range values {
+			if *value, err = strconv.ParseFloat(fields[i+1], 64); err != nil {
+				valid = false
+			}
+		}
+		if valid {
+			boxes = append(boxes, box)
+		}
+	}
+	return boxes
+}
+
+// ParagraphRaw is a p element of a section, with the sentences Grobid segments it into when asked to
+type ParagraphRaw struct {
+	Text      string     `json:"text"`
+	Coords    []Box      `json:"coords"`
+	Sentences []Sentence `json:"sentences"`
+	Citations []Citation `json:"citations"`
+}
+
+type Sentence struct {
+	Text   string `json:"text"`
+	Coords []Box  `json:"coords"`
+}
+
+// UnmarshalXML keeps the text directly inside the paragraph and its sentences and skips other elements, like decoding
+// the paragraph into a string always has, so section texts stay the same. Citation markers are kept aside with their offset.
+func (p *ParagraphRaw) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	p.Coords = ParseCoords(attr(start, "coords"))
+	var text strings.Builder
+	if err := p.readContent(d, &text, true); err != nil {
+		return err
+	}
+	p.Text = text.String()
+	return nil
+}
+
+// readContent reads up to the end of the current element, sentences are only looked for directly inside the paragraph
+func (p *ParagraphRaw) readContent(d *xml.Decoder, text *strings.Builder, sentences bool) error {
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.CharData:
+			text.Write(t)
+		case xml.StartElement:
+			switch {
+			case t.Name.Local == "s" && sentences:
+				// sentences follow each other without whitespace in the TEI
+				if text.Len() > 0 && !unicode.IsSpace(rune(text.String()[text.Len()-1])) {
+					text.WriteByte(' ')
+				}
+				sentenceStart := text.Len()
+				if err := p.readContent(d, text, false); err != nil {
+					return err
+				}
+				p.Sentences = append(p.Sentences, Sentence{Text: strings.TrimSpace(text.String()[sentenceStart:]), Coords: ParseCoords(attr(t, "coords"))})
+			case t.Name.Local == "ref" && attr(t, "type") == "bibr":
+				var ref struct {
+					Text string `xml:",chardata"`
+				}
+				if err := d.DecodeElement(&ref, &t); err != nil {
+					return err
+				}
+				p.Citations = append(p.Citations, Citation{
+					Offset: utf8.RuneCountInString(text.String()),
+					Marker: collapseSpace(ref.Text),
+					Target: strings.TrimPrefix(attr(t, "target"), "#"),
+				})
+			default:
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// Boxes are the paragraph's coords, or those of its sentences when Grobid only placed the sentences
+func (p ParagraphRaw) Boxes() []Box {
+	if len(p.Coords) > 0 {
+		return p.Coords
+	}
+	var boxes []Box
+	for _, sentence := range p.Sentences {
+		boxes = append(boxes, sentence.Coords...)
+	}
+	return boxes
+}
+
+func attr(start xml.StartElement, name string) string {
+	for _, a := range start.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,60 @@
+package parsing
+
+import (
+	"reflect"
+	"testing"
+)
+
+const coordsTEI = `<?xml version="1.0" encoding="UTF-8"?>
+<TEI xmlns="http://www.tei-c.org/ns/1.0">
+	<text>
+		<body>
+			<div>
+				<head>Introduction</head>
+				<p coords="1,53.80,96.21,240.10,8.97;2,53.80,60.00,120.00,8.97"><s coords="1,53.80,96.21,240.10,8.97">Azoles are fungicides <ref type="bibr" target="#b0">[1]</ref>.</s><s coords="2,53.80,60.00,120.00,8.97">They reach rivers.</s></p>
+				<p><s coords="2,53.80,80.00,100.00,8.97">Only the sentence is placed.</s></p>
+				<p>An unsegmented paragraph <ref type="bibr">[2]</ref>.</p>
+			</div>
+		</body>
+	</text>
+</TEI>`
+
+// test paragraphs keep their text when segmented into sentences and carry their coordinates
+func TestParagraphCoords(t *testing.T) {
+	crude, err := ParseGrobidResponse([]byte(coordsTEI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	paragraphs := crude.Sections[0].P
+	if len(paragraphs) != 3 {
+		t.Fatalf("Expected 3 paragraphs, got %d", len(paragraphs))
+	}
+
+	first := paragraphs[0]
+	if first.Text != "Azoles are fungicides . They reach rivers." {
+		t.Errorf("Unexpected text %q", first.Text)
+	}
+	wantBoxes := []Box{{Page: 1, X: 53.8, Y: 96.21, Width: 240.1, Height: 8.97}, {Page: 2, X: 53.8, Y: 60, Width: 120, Height: 8.97}}
+	if !reflect.DeepEqual(first.Boxes(), wantBoxes) {
+		t.Errorf("Unexpected boxes %+v", first.Boxes())
+	}
+	if len(first.Sentences) != 2 || first.Sentences[1].Text != "They reach rivers." || !reflect.DeepEqual(first.Sentences[1].Coords, wantBoxes[1:]) {
+		t.Errorf("Unexpected sentences %+v", first.Sentences)
+	}
+
+	if boxes := paragraphs[1].Boxes(); !reflect.DeepEqual(boxes, []Box{{Page: 2, X: 53.8, Y: 80, Width: 100, Height: 8.97}}) {
+		t.Errorf("Expected the sentence boxes, got %+v", boxes)
+	}
+	if paragraphs[2].Text != "An unsegmented paragraph ." || paragraphs[2].Boxes() != nil {
+		t.Errorf("Unexpected paragraph %+v", paragraphs[2])
+	}
+}
+
+// test malformed boxes are skipped
+func TestParseCoords(t *testing.T) {
+	boxes := ParseCoords("1,1,2,3,4;x,1,2,3,4;2,1,2,3;3,1,2,3,y;4,5,6,7,8")
+	want := []Box{{Page: 1, X: 1, Y: 2, Width: 3, Height: 4}, {Page: 4, X: 5, Y: 6, Width: 7, Height: 8}}
+	if !reflect.DeepEqual(boxes, want) {
+		t.Errorf("Unexpected boxes %+v", boxes)
+	}
+}
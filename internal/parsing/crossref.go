@@ -46,6 +46,32 @@ type CrossRefTitleResponse struct {
 	} `json:"message"`
 }
 
+// CrossReference looks the paper up on Crossref by DOI, falling back to the title. Lookup errors are logged
+// and an empty response returned, since Crossref data is only used to improve what Grobid found.
+func CrossReference(tidyGrobidResponse *TidyGrobidResponse) *TidyCrossRefResponse {
+	crossRefResponse := &TidyCrossRefResponse{}
+	var err error
+
+	// Cross reference data using the DOI
+	if tidyGrobidResponse.Doi != "" {
+		crossRefResponse, err = CrossRefDataDOI(tidyGrobidResponse.Doi)
+		if err != nil {
+			log.Println("Error cross referencing data using DOI:", err)
+		}
+	}
+
+	// If DOI is not available or failed, try cross-referencing using Title
+	if crossRefResponse.DOI == "" && tidyGrobidResponse.Title != "" {
+		crossRefResponse, err = CrossRefDataTitle(tidyGrobidResponse.Title)
+		if err != nil {
+			log.Println("Error cross referencing data using Title:", err)
+			crossRefResponse = &TidyCrossRefResponse{}
+		}
+	}
+
+	return crossRefResponse
+}
+
 func CrossRefDataDOI(doi string) (*TidyCrossRefResponse, error) {
 	log.Printf("Cross referencing data for DOI: %s\n", doi)
 
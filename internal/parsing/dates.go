@@ -0,0 +1,127 @@
+package parsing
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DatePrecision says which parts of a PublishedDate are known
+type DatePrecision string
+
+const (
+	DatePrecisionYear  DatePrecision = "year"
+	DatePrecisionMonth DatePrecision = "month"
+	DatePrecisionDay   DatePrecision = "day"
+)
+
+// earliestYear is older than any paper we screen, dates before it are misread page numbers or volumes
+const earliestYear = 1600
+
+// PublishedDate is when a paper was published, as far as the PDF tells. Month and day are 0 when unknown.
+type PublishedDate struct {
+	Year      int           `json:"year"`
+	Month     int           `json:"month,omitempty"`
+	Day       int           `json:"day,omitempty"`
+	Precision DatePrecision `json:"precision"`
+}
+
+type dateLayout struct {
+	layout    string
+	precision DatePrecision
+}
+
+// isoLayouts are the forms of the when attribute, most precise first
+var isoLayouts = []dateLayout{
+	{"2006-01-02", DatePrecisionDay},
+	{"2006-01", DatePrecisionMonth},
+	{"2006", DatePrecisionYear},
+}
+
+// textLayouts are the printed dates Grobid leaves as they are, most precise first
+var textLayouts = []dateLayout{
+	{"2 January 2006", DatePrecisionDay},
+	{"2 Jan 2006", DatePrecisionDay},
+	{"January 2, 2006", DatePrecisionDay},
+	{"Jan 2, 2006", DatePrecisionDay},
+	{"January 2 2006", DatePrecisionDay},
+	{"2006/01/02", DatePrecisionDay},
+	{"January 2006", DatePrecisionMonth},
+	{"Jan 2006", DatePrecisionMonth},
+	{"January, 2006", DatePrecisionMonth},
+	{"2006/01", DatePrecisionMonth},
+}
+
+var yearRegex = regexp.MustCompile(`\b(1[6-9]|20)\d{2}\b`)
+
+// ParseDate reads a TEI date from its when attribute, which Grobid normalises to an ISO date, and falls back to the
+// printed text: known formats first, then any year in it, such as in "Summer 2019". It returns nil for dates like
+// "n.d." and for implausible years.
+func ParseDate(when, text string) *PublishedDate {
+	if date := parseLayouts(strings.TrimSpace(when), isoLayouts); date != nil {
+		return date
+	}
+	text = strings.TrimSuffix(collapseSpace(text), ".")
+	if date := parseLayouts(text, textLayouts); date != nil {
+		return date
+	}
+	if date := parseLayouts(text, isoLayouts); date != nil {
+		return date
+	}
+	if match := yearRegex.FindString(text); match != "" {
+		year, _ := strconv.Atoi(match)
+		if date := (&PublishedDate{Year: year, Precision: DatePrecisionYear}); date.plausible() {
+			return date
+		}
+	}
+	return nil
+}
+
+func parseLayouts(value string, layouts []dateLayout) *PublishedDate {
+	if value == "" {
+		return nil
+	}
+	for _, layout := range layouts {
+		parsed, err := time.Parse(layout.layout, value)
+		if err != nil {
+			continue
+		}
+		date := &PublishedDate{Year: parsed.Year(), Precision: layout.precision}
+		if layout.precision != DatePrecisionYear {
+			date.Month = int(parsed.Month())
+		}
+		if layout.precision == DatePrecisionDay {
+			date.Day = parsed.Day()
+		}
+		if date.plausible() {
+			return date
+		}
+	}
+	return nil
+}
+
+// plausible rejects years before earliestYear and after next year, papers in press can carry next year's date
+func (d PublishedDate) plausible() bool {
+	return d.Year >= earliestYear && d.Year <= time.Now().Year()+1
+}
+
+// String formats the date as ISO, up to its precision
+func (d PublishedDate) String() string {
+	switch d.Precision {
+	case DatePrecisionDay:
+		return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+	case DatePrecisionMonth:
+		return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
+	}
+	return fmt.Sprintf("%04d", d.Year)
+}
+
+// YearString is the year as the papers table stores it, empty without a date
+func (d *PublishedDate) YearString() string {
+	if d == nil {
+		return ""
+	}
+	return strconv.Itoa(d.Year)
+}
@@ -0,0 +1,38 @@
+package parsing
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// test the when attribute is read with its precision and printed dates are read as a fallback
+func TestParseDate(t *testing.T) {
+	nextYear := time.Now().Year() + 1
+	tests := []struct {
+		when string
+		text string
+		want *PublishedDate
+	}{
+		{"2020-07-04", "4 July 2020", &PublishedDate{Year: 2020, Month: 7, Day: 4, Precision: DatePrecisionDay}},
+		{"2020-07", "", &PublishedDate{Year: 2020, Month: 7, Precision: DatePrecisionMonth}},
+		{"2019", "Summer 2019", &PublishedDate{Year: 2019, Precision: DatePrecisionYear}},
+		{"", "4 July 2020", &PublishedDate{Year: 2020, Month: 7, Day: 4, Precision: DatePrecisionDay}},
+		{"", "July 4, 2020", &PublishedDate{Year: 2020, Month: 7, Day: 4, Precision: DatePrecisionDay}},
+		{"", "Jul 2020.", &PublishedDate{Year: 2020, Month: 7, Precision: DatePrecisionMonth}},
+		{"", "2020-07", &PublishedDate{Year: 2020, Month: 7, Precision: DatePrecisionMonth}},
+		{"", "Summer 2019", &PublishedDate{Year: 2019, Precision: DatePrecisionYear}},
+		{"", "n.d.", nil},
+		{"", "", nil},
+		{"0201", "", nil},
+		{fmt.Sprintf("%d", nextYear), "", &PublishedDate{Year: nextYear, Precision: DatePrecisionYear}},
+		{fmt.Sprintf("%d", nextYear+5), "", nil},
+		{"garbage", "4 July 2020", &PublishedDate{Year: 2020, Month: 7, Day: 4, Precision: DatePrecisionDay}},
+	}
+	for _, test := range tests {
+		if got := ParseDate(test.when, test.text); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ParseDate(%q, %q): expected %+v, got %+v", test.when, test.text, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package parsing
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+)
+
+// Figure types, Grobid marks tables with type="table" and leaves figures untyped
+const (
+	FigureTypeFigure = "figure"
+	FigureTypeTable  = "table"
+)
+
+type FigureRaw struct {
+	ID      string   `xml:"http://www.w3.org/XML/1998/namespace id,attr"`
+	Type    string   `xml:"type,attr"`
+	Coords  string   `xml:"coords,attr"`
+	Head    string   `xml:"head"`
+	Label   string   `xml:"label"`
+	FigDesc InnerRaw `xml:"figDesc"`
+	Rows    []RowRaw `xml:"table>row"`
+}
+
+type RowRaw struct {
+	Cells []CellRaw `xml:"cell"`
+}
+
+type CellRaw struct {
+	Cols    string `xml:"cols,attr"`
+	Content string `xml:",innerxml"`
+}
+
+// InnerRaw keeps the markup of an element whose text may be split across children
+type InnerRaw struct {
+	Content string `xml:",innerxml"`
+}
+
+// Figure is a figure or a table of a paper
+type Figure struct {
+	TEIID   string `json:"tei_id"`
+	Type    string `json:"type"`
+	Label   string `json:"label"`
+	Caption string `json:"caption"`
+	// Rows is the cell grid of a table, a cell spanning columns is followed by empty cells so the columns line up
+	Rows   [][]string `json:"rows"`
+	Coords []Box      `json:"coords"`
+}
+
+var tagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// breakTagRegex matches the tags that separate words, block elements, sentences and line breaks
+var breakTagRegex = regexp.MustCompile(`</?(?:div|p|s|head|lb|list|item|row|cell)(?:\s[^>]*)?/?>`)
+
+// TidyFigures turns the figure elements of a TEI document into figures
+func TidyFigures(raw []FigureRaw) []Figure {
+	var figures []Figure
+	for _, entry := range raw {
+		figure := Figure{
+			TEIID:   entry.ID,
+			Type:    FigureTypeFigure,
+			Label:   collapseSpace(entry.Head),
+			Caption: innerText(entry.FigDesc.Content),
+			Coords:  ParseCoords(entry.Coords),
+		}
+		if entry.Type == FigureTypeTable {
+			figure.Type = FigureTypeTable
+		}
+		// the head is the printed label such as "Table 1", the label element only the number
+		if figure.Label == "" {
+			figure.Label = collapseSpace(entry.Label)
+		}
+		for _, row := range entry.Rows {
+			var cells []string
+			for _, cell := range row.Cells {
+				cells = append(cells, innerText(cell.Content))
+				if span, err := strconv.Atoi(cell.Cols); err == nil {
+					for i := 1; i < span; i++ {
+						cells = append(cells, "")
+					}
+				}
+			}
+			figure.Rows = append(figure.Rows, cells)
+		}
+
+		if figure.Label == "" && figure.Caption == "" && len(figure.Rows) == 0 {
+			continue
+		}
+		figures = append(figures, figure)
+	}
+	return figures
+}
+
+// innerText drops the markup of an element, such as the div and p Grobid wraps captions in.
+// Block and line break tags become a space, other tags are removed without one so inline markup like subscripts
+// stays attached to its word.
+func innerText(content string) string {
+	content = breakTagRegex.ReplaceAllString(content, " ")
+	return collapseSpace(html.UnescapeString(tagRegex.ReplaceAllString(content, "")))
+}
@@ -0,0 +1,69 @@
+package parsing
+
+import (
+	"reflect"
+	"testing"
+)
+
+const figuresTEI = `<?xml version="1.0" encoding="UTF-8"?>
+<TEI xmlns="http://www.tei-c.org/ns/1.0">
+	<text>
+		<body>
+			<div><head>Results</head><p>See Table 1.</p></div>
+			<figure xml:id="fig_0">
+				<head>Fig. 1</head>
+				<label>1</label>
+				<figDesc><div><p>Survival of larvae exposed to tebuconazole.</p></div></figDesc>
+				<graphic url="fig1.png"/>
+			</figure>
+			<figure type="table" xml:id="tab_0">
+				<head>Table 1</head>
+				<label>1</label>
+				<figDesc>Concentrations of H<hi rend="subscript">2</hi>O<hi rend="subscript">2</hi> &amp; controls</figDesc>
+				<table>
+					<row><cell cols="2">Group</cell><cell>n</cell></row>
+					<row><cell>Control</cell><cell>0 mg/L</cell><cell>30</cell></row>
+				</table>
+			</figure>
+			<figure xml:id="fig_1"><graphic url="empty.png"/></figure>
+		</body>
+	</text>
+</TEI>`
+
+// test figures and table cells are read from the body
+func TestTidyFigures(t *testing.T) {
+	crude, err := ParseGrobidResponse([]byte(figuresTEI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Figure{
+		{TEIID: "fig_0", Type: FigureTypeFigure, Label: "Fig. 1", Caption: "Survival of larvae exposed to tebuconazole."},
+		{
+			TEIID:   "tab_0",
+			Type:    FigureTypeTable,
+			Label:   "Table 1",
+			Caption: "Concentrations of H2O2 & controls",
+			Rows:    [][]string{{"Group", "", "n"}, {"Control", "0 mg/L", "30"}},
+		},
+	}
+	if figures := TidyFigures(crude.Figures); !reflect.DeepEqual(figures, want) {
+		t.Errorf("Unexpected figures: %+v", figures)
+	}
+}
+
+// test block and line break tags separate words while inline tags do not
+func TestInnerText(t *testing.T) {
+	tests := map[string]string{
+		"<p>a</p><p>b</p>":                      "a b",
+		"a<lb/>b":                               "a b",
+		`<s coords="1,2,3,4,5">A.</s><s>B.</s>`: "A. B.",
+		`H<hi rend="subscript">2</hi>O`:         "H2O",
+		"<div><p>Survival</p></div>":            "Survival",
+	}
+	for content, want := range tests {
+		if got := innerText(content); got != want {
+			t.Errorf("%q: expected %q, got %q", content, want, got)
+		}
+	}
+}
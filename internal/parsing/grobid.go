@@ -1,16 +1,11 @@
 package parsing
 
 import (
-	"bytes"
+	"context"
 	"encoding/xml"
 	"fmt"
-	"simple-go-app/internal/helpers"
 	//"github.com/uniplaces/carbon"
-	"io"
-	"io/ioutil"
 	"log"
-	"mime/multipart"
-	"net/http"
 	"regexp"
 	"strings"
 	"sync"
@@ -18,38 +13,49 @@ import (
 
 // CrudeGrobidResponse represents the structure of the Grobid service response.
 type CrudeGrobidResponse struct {
-	Raw      string       `xml:",innerxml"`
-	IDNOs    []IdnosRaw   `xml:"teiHeader>fileDesc>sourceDesc>biblStruct>idno"`
-	Keywords KeywordsRaw  `xml:"teiHeader>profileDesc>textClass>keywords"`
-	Title    string       `xml:"teiHeader>fileDesc>titleStmt>title"`
-	Date     string       `xml:"teiHeader>fileDesc>publicationStmt>date"`
-	Abstract string       `xml:"teiHeader>profileDesc>abstract>div>p"`
-	Sections []SectionRaw `xml:"text>body>div"`
-	Authors  []AuthorsRaw `xml:"teiHeader>fileDesc>sourceDesc>biblStruct>analytic>author"`
+	// TEI is the document Grobid returned, kept so the paper can be reprocessed without Grobid
+	TEI        []byte          `xml:"-"`
+	Raw        string          `xml:",innerxml"`
+	IDNOs      []BiblIdnoRaw   `xml:"teiHeader>fileDesc>sourceDesc>biblStruct>idno"`
+	Keywords   KeywordsRaw     `xml:"teiHeader>profileDesc>textClass>keywords"`
+	Title      string          `xml:"teiHeader>fileDesc>titleStmt>title"`
+	Date       BiblDateRaw     `xml:"teiHeader>fileDesc>publicationStmt>date"`
+	Abstract   AbstractRaw     `xml:"teiHeader>profileDesc>abstract"`
+	Sections   []SectionRaw    `xml:"text>body>div"`
+	Authors    []AuthorsRaw    `xml:"teiHeader>fileDesc>sourceDesc>biblStruct>analytic>author"`
+	Monogr     BiblLevelRaw    `xml:"teiHeader>fileDesc>sourceDesc>biblStruct>monogr"`
+	Notes      []BiblNoteRaw   `xml:"teiHeader>fileDesc>notesStmt>note"`
+	BodyNotes  []BiblNoteRaw   `xml:"text>body>note"`
+	References []BiblStructRaw `xml:"text>back>div>listBibl>biblStruct"`
+	Figures    []FigureRaw     `xml:"text>body>figure"`
 }
 
 type TidyGrobidResponse struct {
-	Doi      string       `json:"doi"`
-	Keywords []string     `json:"keywords"`
-	Title    string       `json:"title"`
-	Date     string       `json:"date"`
-	Year     string       `json:"year"`
-	Abstract string       `json:"abstract"`
-	Sections []SectionRaw `json:"sections"`
-	Authors  []AuthorsRaw `json:"authors"`
-	Journal  string       `json:"journal"`
-	Notes    string       `json:"notes"`
-	ISSN     string       `json:"issn"`
-}
-
-type IdnosRaw struct {
-	RawContent string `xml:",innerxml"`
+	Doi         string      `json:"doi"`
+	Identifiers Identifiers `json:"identifiers"`
+	Keywords    []string    `json:"keywords"`
+	Title       string      `json:"title"`
+	Date        string      `json:"date"`
+	Year        string      `json:"year"`
+	// PublishedDate is nil when the date could not be read
+	PublishedDate *PublishedDate `json:"published_date"`
+	Abstract      string         `json:"abstract"`
+	AbstractParts []AbstractPart `json:"abstract_parts"`
+	Sections      []Section      `json:"sections"`
+	Authors       []Author       `json:"authors"`
+	Journal       string         `json:"journal"`
+	Notes         string         `json:"notes"`
+	ISSN          string         `json:"issn"`
+	References    []Reference    `json:"references"`
+	Figures       []Figure       `json:"figures"`
+	Venue
 }
 
 type SectionRaw struct {
-	RawContent string   `xml:",innerxml"`
-	Head       string   `xml:"head"`
-	P          []string `xml:"p"`
+	RawContent string         `xml:",innerxml"`
+	Head       HeadRaw        `xml:"head"`
+	P          []ParagraphRaw `xml:"p"`
+	Divs       []SectionRaw   `xml:"div"`
 }
 
 type KeywordsRaw struct {
@@ -58,31 +64,21 @@ type KeywordsRaw struct {
 }
 
 type AuthorsRaw struct {
-	RawContent string `xml:",innerxml"`
+	RawContent   string           `xml:",innerxml"`
+	Role         string           `xml:"role,attr"`
+	PersName     PersNameRaw      `xml:"persName"`
+	Email        string           `xml:"email"`
+	IDNOs        []BiblIdnoRaw    `xml:"idno"`
+	Affiliations []AffiliationRaw `xml:"affiliation"`
 }
 
-func CheckGrobidHealth(healthStatus *bool, healthMutex *sync.Mutex, fn ...func()) {
+func CheckGrobidHealth(grobid Grobid, healthStatus *bool, healthMutex *sync.Mutex, fn ...func()) {
 	fmt.Println("Periodic health check")
-	healthMutex.Lock()
-	healthMutex.Unlock()
-	GrobidURL := helpers.GetEnvVariable("GROBID_URL")
-	healthEndpoint := "/api/isalive"
-	// Attempt to make a GET request to the Grobid health endpoint
-	resp, err := http.Get(GrobidURL + healthEndpoint)
+	err := grobid.IsAlive(context.Background())
 	if err != nil {
 		fmt.Println("Error checking Grobid health:", err)
-		*healthStatus = false
-		return
 	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			fmt.Println("Error closing Grobid response body:", err)
-		}
-	}(resp.Body)
-
-	// Check if the response status code is within the 2xx range
-	isHealthy := resp.StatusCode >= 200 && resp.StatusCode < 300
+	isHealthy := err == nil
 
 	if isHealthy {
 		// start up workers
@@ -91,69 +87,30 @@ func CheckGrobidHealth(healthStatus *bool, healthMutex *sync.Mutex, fn ...func()
 		}
 	}
 	fmt.Println("Setting Grobid health status to", isHealthy)
+	healthMutex.Lock()
 	*healthStatus = isHealthy
+	healthMutex.Unlock()
 }
 
-func SendPDF2Grobid(fileContent []byte) (*CrudeGrobidResponse, error) {
-	// Create a buffer to store the multipart form data
-	var requestBody bytes.Buffer
-	writer := multipart.NewWriter(&requestBody)
-
-	// Add the file field to the request
-	fileField, err := writer.CreateFormField("input")
-	if err != nil {
-		return nil, err
-	}
-
-	// Copy the file content to the form file field
-	_, err = io.Copy(fileField, bytes.NewReader(fileContent))
-	if err != nil {
-		return nil, err
-	}
-
-	// Add other form fields
-	err = writer.WriteField("consolidateHeader", "1")
-	if err != nil {
-		return nil, err
-	}
-
-	// Close the multipart writer
-	err = writer.Close()
-	if err != nil {
-		return nil, err
-	}
-
-	GrobidURL := helpers.GetEnvVariable("GROBID_URL")
-	// Make a POST request to the Grobid service endpoint
-	resp, err := http.Post(GrobidURL+"/api/processFulltextDocument", writer.FormDataContentType(), &requestBody)
-	if err != nil {
-		return nil, err
-	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			fmt.Println("Error closing Grobid response body:", err)
-		}
-	}(resp.Body)
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("grobid service returned non-OK status: %v", resp.Status)
-	}
-
-	// Read Grobid service response
-	grobidResponse, err := ioutil.ReadAll(resp.Body)
+// SendPDF2Grobid processes a PDF with opts, FulltextOptions unless a request or screen changed them, and parses the TEI
+func SendPDF2Grobid(ctx context.Context, grobid Grobid, pdf PDFOpener, opts ProcessOptions) (*CrudeGrobidResponse, error) {
+	tei, err := grobid.ProcessFulltextStream(ctx, pdf, opts)
 	if err != nil {
 		return nil, err
 	}
 	fmt.Println("Grobid successfully processed the file")
-	//println(string(grobidResponse))
 
-	// Parse XML response
+	return ParseGrobidResponse(tei)
+}
+
+// ParseGrobidResponse reads a TEI document returned by Grobid
+func ParseGrobidResponse(tei []byte) (*CrudeGrobidResponse, error) {
 	var parsedGrobidResponse CrudeGrobidResponse
-	err = xml.Unmarshal(grobidResponse, &parsedGrobidResponse)
+	err := xml.Unmarshal(tei, &parsedGrobidResponse)
 	if err != nil {
 		return nil, err
 	}
+	parsedGrobidResponse.TEI = tei
 
 	return &parsedGrobidResponse, nil
 }
@@ -161,13 +118,9 @@ func SendPDF2Grobid(fileContent []byte) (*CrudeGrobidResponse, error) {
 func TidyUpGrobidResponse(crudeResponse *CrudeGrobidResponse) (*TidyGrobidResponse, error) {
 	var tidyResponse TidyGrobidResponse
 
-	//log.Printf("Crude IDNOs: %s\n", crudeResponse.IDNOs[1].RawContent)
-
-	if len(crudeResponse.IDNOs) > 1 {
-		tidyResponse.Doi = GetDOIFromString(crudeResponse.IDNOs[1].RawContent)
-	} else {
-		tidyResponse.Doi = GetDOIFromString(crudeResponse.IDNOs[0].RawContent)
-	}
+	tidyResponse.Identifiers = TidyIdentifiers(crudeResponse.IDNOs)
+	tidyResponse.Identifiers.Scan(firstPageText(crudeResponse))
+	tidyResponse.Doi = tidyResponse.Identifiers.DOI
 	tidyResponse.Keywords = crudeResponse.Keywords.Term
 
 	// if keywords are empty, try to extract them from raw content
@@ -176,18 +129,43 @@ func TidyUpGrobidResponse(crudeResponse *CrudeGrobidResponse) (*TidyGrobidRespon
 	}
 
 	tidyResponse.Title = crudeResponse.Title
-	tidyResponse.Date = crudeResponse.Date
-	if tidyResponse.Date != "" {
-		// Hopefully the date is in this format  4 July 2020
-		tidyResponse.Year = tidyResponse.Date[len(tidyResponse.Date)-4:]
+	// Grobid puts the date in the publication statement, or only in the journal's imprint for some layouts
+	date := crudeResponse.Date
+	if ParseDate(date.When, date.Text) == nil {
+		date = crudeResponse.Monogr.Date
 	}
-	tidyResponse.Abstract = crudeResponse.Abstract
-	for _, section := range crudeResponse.Sections {
-		tidyResponse.Sections = append(tidyResponse.Sections, section)
+	tidyResponse.Date = collapseSpace(date.Text)
+	tidyResponse.PublishedDate = ParseDate(date.When, date.Text)
+	tidyResponse.Year = tidyResponse.PublishedDate.YearString()
+	tidyResponse.AbstractParts = TidyAbstract(crudeResponse.Abstract)
+	tidyResponse.Abstract = FlattenAbstract(tidyResponse.AbstractParts)
+
+	// the journal the paper was published in
+	monogr := crudeResponse.Monogr
+	tidyResponse.Journal = monogr.title()
+	tidyResponse.ISSN = normalizeISSN(monogr.idno("ISSN"))
+	if tidyResponse.ISSN == "" {
+		tidyResponse.ISSN = normalizeISSN(monogr.idno("pISSN"))
 	}
-	for _, author := range crudeResponse.Authors {
-		tidyResponse.Authors = append(tidyResponse.Authors, author)
+	tidyResponse.Venue = Venue{
+		JournalAbbreviation: upTo(monogr.abbreviatedTitle(), maxNameLength),
+		EISSN:               normalizeISSN(monogr.idno("eISSN")),
+		Volume:              upTo(monogr.scope("volume"), maxScopeLength),
+		Issue:               upTo(monogr.scope("issue"), maxScopeLength),
+		Pages:               upTo(monogr.scope("page"), maxScopeLength),
+		Publisher:           upTo(collapseSpace(monogr.Publisher), maxNameLength),
+	}
+	var notes []string
+	for _, note := range crudeResponse.Notes {
+		if text := innerText(note.Text); text != "" {
+			notes = append(notes, text)
+		}
 	}
+	tidyResponse.Notes = strings.Join(notes, "\n")
+	tidyResponse.Sections = TidySections(crudeResponse.Sections)
+	tidyResponse.Authors = TidyAuthors(crudeResponse.Authors)
+	tidyResponse.References = TidyReferences(crudeResponse.References)
+	tidyResponse.Figures = TidyFigures(crudeResponse.Figures)
 	return &tidyResponse, nil
 }
 
@@ -269,8 +247,7 @@ func GetDOIFromString(content string) string {
 	}
 
 	// Use regular expression to extract DOI
-	DOIRegex := regexp.MustCompile(`\b(10\.[0-9]{4,}(?:\.[0-9]+)*/\S+)\b`)
-	matches := DOIRegex.FindStringSubmatch(content)
+	matches := doiRegex.FindStringSubmatch(content)
 
 	// If matches are found, extract DOI
 	if len(matches) > 0 {
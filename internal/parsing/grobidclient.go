@@ -0,0 +1,341 @@
+package parsing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"simple-go-app/internal/helpers"
+	"simple-go-app/internal/metrics"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Grobid is the Grobid service API, implemented by GrobidClient and faked in tests.
+// The process methods return the TEI document Grobid produced.
+type Grobid interface {
+	IsAlive(ctx context.Context) error
+	Version(ctx context.Context) (string, error)
+	ProcessFulltextDocument(ctx context.Context, pdf []byte, opts ProcessOptions) ([]byte, error)
+	ProcessFulltextStream(ctx context.Context, pdf PDFOpener, opts ProcessOptions) ([]byte, error)
+	ProcessHeaderDocument(ctx context.Context, pdf []byte, opts ProcessOptions) ([]byte, error)
+	ProcessReferences(ctx context.Context, pdf []byte, opts ProcessOptions) ([]byte, error)
+	ProcessCitation(ctx context.Context, citation string, opts ProcessOptions) ([]byte, error)
+	ProcessCitationList(ctx context.Context, citations []string, opts ProcessOptions) ([]byte, error)
+	ProcessAffiliations(ctx context.Context, affiliations string) ([]byte, error)
+}
+
+// Consolidation levels for ProcessOptions, see the Grobid documentation
+const (
+	ConsolidateNone     = 0
+	ConsolidateFull     = 1
+	ConsolidateDOIOnly  = 2
+	ConsolidateDOIMerge = 3
+)
+
+// ProcessOptions are the form parameters the process endpoints accept. Each endpoint sends the ones it supports.
+type ProcessOptions struct {
+	ConsolidateHeader      int
+	ConsolidateCitations   int
+	IncludeRawCitations    bool
+	IncludeRawAffiliations bool
+	SegmentSentences       bool
+	// TEICoordinates lists the TEI elements to add PDF coordinates to, such as "s" or "figure"
+	TEICoordinates []string
+	// Start and End limit the pages processed, 0 for the first and last page
+	Start int
+	End   int
+}
+
+// FulltextOptions returns the options the pipeline processes PDFs with, raw citations are kept for the references
+// table and coordinates let the front end highlight paragraphs, sentences, figures and references in the PDF
+func FulltextOptions() ProcessOptions {
+	return ProcessOptions{
+		ConsolidateHeader:   ConsolidateFull,
+		IncludeRawCitations: true,
+		SegmentSentences:    true,
+		TEICoordinates:      []string{"p", "s", "figure", "biblStruct"},
+	}
+}
+
+// GrobidConfig configures a GrobidClient
+type GrobidConfig struct {
+	BaseURL string
+	// Timeout bounds each attempt, not the call as a whole. A process request that times out is not retried, as
+	// Grobid would most likely time out on the same document again.
+	Timeout time.Duration
+	// Retries is how many times a request is retried after a network error or a 503, which Grobid returns when busy
+	Retries      int
+	RetryBackoff time.Duration
+	HTTPClient   *http.Client
+}
+
+// GrobidError is returned when Grobid answers with a status other than 200
+type GrobidError struct {
+	Endpoint   string
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *GrobidError) Error() string {
+	return fmt.Sprintf("grobid service returned non-OK status: %v", e.Status)
+}
+
+// GrobidClient calls a Grobid service over HTTP
+type GrobidClient struct {
+	baseURL      string
+	timeout      time.Duration
+	retries      int
+	retryBackoff time.Duration
+	httpClient   *http.Client
+}
+
+// NewGrobidClient creates a GrobidClient
+func NewGrobidClient(cfg GrobidConfig) *GrobidClient {
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	return &GrobidClient{
+		baseURL:      strings.TrimRight(cfg.BaseURL, "/"),
+		timeout:      cfg.Timeout,
+		retries:      cfg.Retries,
+		retryBackoff: cfg.RetryBackoff,
+		httpClient:   httpClient,
+	}
+}
+
+// NewGrobidClientFromEnv creates a GrobidClient for GROBID_URL
+func NewGrobidClientFromEnv() *GrobidClient {
+	return NewGrobidClient(GrobidConfig{
+		BaseURL:      helpers.GetEnvVariable("GROBID_URL"),
+		Timeout:      time.Duration(helpers.GetEnvIntDefault("GROBID_TIMEOUT_SECONDS", 300)) * time.Second,
+		Retries:      helpers.GetEnvIntDefault("GROBID_RETRIES", 2),
+		RetryBackoff: time.Duration(helpers.GetEnvIntDefault("GROBID_RETRY_BACKOFF_MS", 1000)) * time.Millisecond,
+	})
+}
+
+// IsAlive checks the Grobid service reports itself alive
+func (g *GrobidClient) IsAlive(ctx context.Context) error {
+	_, err := g.do(ctx, http.MethodGet, "isalive", nil)
+	return err
+}
+
+// Version returns the version of the Grobid service
+func (g *GrobidClient) Version(ctx context.Context) (string, error) {
+	body, err := g.do(ctx, http.MethodGet, "version", nil)
+	if err != nil {
+		return "", err
+	}
+	// recent versions answer with JSON, older ones with the bare version
+	var version struct {
+		Version string `json:"version"`
+	}
+	if json.Unmarshal(body, &version) == nil && version.Version != "" {
+		return version.Version, nil
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// ProcessFulltextDocument extracts the header, body and references of a PDF
+func (g *GrobidClient) ProcessFulltextDocument(ctx context.Context, pdf []byte, opts ProcessOptions) ([]byte, error) {
+	return g.ProcessFulltextStream(ctx, BytesPDF(pdf), opts)
+}
+
+// ProcessFulltextStream is ProcessFulltextDocument for a PDF streamed to Grobid as it is read
+func (g *GrobidClient) ProcessFulltextStream(ctx context.Context, pdf PDFOpener, opts ProcessOptions) ([]byte, error) {
+	fields := append(opts.headerFields(), opts.citationFields()...)
+	fields = append(fields, opts.layoutFields()...)
+	fields = append(fields, opts.pageFields()...)
+	return g.post(ctx, "processFulltextDocument", pdf, fields)
+}
+
+// ProcessHeaderDocument extracts the header of a PDF
+func (g *GrobidClient) ProcessHeaderDocument(ctx context.Context, pdf []byte, opts ProcessOptions) ([]byte, error) {
+	return g.post(ctx, "processHeaderDocument", BytesPDF(pdf), opts.headerFields())
+}
+
+// ProcessReferences extracts the bibliography of a PDF
+func (g *GrobidClient) ProcessReferences(ctx context.Context, pdf []byte, opts ProcessOptions) ([]byte, error) {
+	return g.post(ctx, "processReferences", BytesPDF(pdf), opts.citationFields())
+}
+
+// ProcessCitation parses a single raw citation string
+func (g *GrobidClient) ProcessCitation(ctx context.Context, citation string, opts ProcessOptions) ([]byte, error) {
+	fields := append([]field{{"citations", citation}}, opts.citationFields()...)
+	return g.post(ctx, "processCitation", nil, fields)
+}
+
+// ProcessCitationList parses several raw citation strings in one request
+func (g *GrobidClient) ProcessCitationList(ctx context.Context, citations []string, opts ProcessOptions) ([]byte, error) {
+	var fields []field
+	for _, citation := range citations {
+		fields = append(fields, field{"citations", citation})
+	}
+	fields = append(fields, opts.citationFields()...)
+	return g.post(ctx, "processCitationList", nil, fields)
+}
+
+// ProcessAffiliations parses a raw affiliation string
+func (g *GrobidClient) ProcessAffiliations(ctx context.Context, affiliations string) ([]byte, error) {
+	return g.post(ctx, "processAffiliations", nil, []field{{"affiliations", affiliations}})
+}
+
+type field struct {
+	name  string
+	value string
+}
+
+func (o ProcessOptions) headerFields() []field {
+	fields := []field{{"consolidateHeader", strconv.Itoa(o.ConsolidateHeader)}}
+	if o.IncludeRawAffiliations {
+		fields = append(fields, field{"includeRawAffiliations", "1"})
+	}
+	return fields
+}
+
+func (o ProcessOptions) citationFields() []field {
+	fields := []field{{"consolidateCitations", strconv.Itoa(o.ConsolidateCitations)}}
+	if o.IncludeRawCitations {
+		fields = append(fields, field{"includeRawCitations", "1"})
+	}
+	return fields
+}
+
+func (o ProcessOptions) layoutFields() []field {
+	var fields []field
+	if o.SegmentSentences {
+		fields = append(fields, field{"segmentSentences", "1"})
+	}
+	for _, element := range o.TEICoordinates {
+		fields = append(fields, field{"teiCoordinates", element})
+	}
+	return fields
+}
+
+func (o ProcessOptions) pageFields() []field {
+	var fields []field
+	if o.Start > 0 {
+		fields = append(fields, field{"start", strconv.Itoa(o.Start)})
+	}
+	if o.End > 0 {
+		fields = append(fields, field{"end", strconv.Itoa(o.End)})
+	}
+	return fields
+}
+
+// post sends a multipart form to a process endpoint, with the PDF in the input field when there is one
+func (g *GrobidClient) post(ctx context.Context, endpoint string, pdf PDFOpener, fields []field) ([]byte, error) {
+	return g.do(ctx, http.MethodPost, endpoint, func(ctx context.Context) (io.ReadCloser, string) {
+		return multipartBody(ctx, pdf, fields)
+	})
+}
+
+// multipartBody writes the form through a pipe as the request reads it, so the PDF is never held in memory
+func multipartBody(ctx context.Context, pdf PDFOpener, fields []field) (io.ReadCloser, string) {
+	reader, pipe := io.Pipe()
+	writer := multipart.NewWriter(pipe)
+	go func() {
+		pipe.CloseWithError(writeForm(ctx, writer, pdf, fields))
+	}()
+	return reader, writer.FormDataContentType()
+}
+
+func writeForm(ctx context.Context, writer *multipart.Writer, pdf PDFOpener, fields []field) error {
+	if pdf != nil {
+		part, err := writer.CreateFormFile("input", "input.pdf")
+		if err != nil {
+			return err
+		}
+		content, err := pdf(ctx)
+		if err != nil {
+			return &pdfReadError{err}
+		}
+		defer content.Close()
+		if _, err := io.Copy(part, content); err != nil {
+			// the request closing the pipe early is reported by the request itself
+			if errors.Is(err, io.ErrClosedPipe) {
+				return err
+			}
+			return &pdfReadError{err}
+		}
+	}
+	for _, f := range fields {
+		if err := writer.WriteField(f.name, f.value); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// do calls an endpoint, retrying network errors and 503s with a growing backoff. body creates the request body and
+// its content type for each attempt, it is nil for requests without a body. A POST whose attempt timed out is not
+// retried, so one document Grobid hangs on holds a worker for Timeout rather than Retries+1 times it.
+func (g *GrobidClient) do(ctx context.Context, method, endpoint string, body func(ctx context.Context) (io.ReadCloser, string)) ([]byte, error) {
+	var err error
+	for attempt := 0; attempt <= g.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(g.retryBackoff * time.Duration(attempt)):
+			}
+		}
+
+		var response []byte
+		response, err = g.attempt(ctx, method, endpoint, body)
+		var grobidErr *GrobidError
+		var pdfErr *pdfReadError
+		if err == nil || ctx.Err() != nil || errors.As(err, &pdfErr) || (errors.As(err, &grobidErr) && grobidErr.StatusCode != http.StatusServiceUnavailable) {
+			return response, err
+		}
+		if method == http.MethodPost && errors.Is(err, context.DeadlineExceeded) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+func (g *GrobidClient) attempt(ctx context.Context, method, endpoint string, body func(ctx context.Context) (io.ReadCloser, string)) ([]byte, error) {
+	if g.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.timeout)
+		defer cancel()
+	}
+
+	var reader io.ReadCloser = http.NoBody
+	contentType := ""
+	if body != nil {
+		reader, contentType = body(ctx)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, g.baseURL+"/api/"+endpoint, reader)
+	if err != nil {
+		reader.Close()
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		metrics.ObserveGrobidResponse(endpoint, 0, err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	metrics.ObserveGrobidResponse(endpoint, resp.StatusCode, nil)
+
+	response, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &GrobidError{Endpoint: endpoint, StatusCode: resp.StatusCode, Status: resp.Status, Body: string(response)}
+	}
+	return response, nil
+}
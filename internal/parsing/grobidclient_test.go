@@ -0,0 +1,189 @@
+package parsing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// test the form fields sent for a full text request
+func TestGrobidClient_ProcessFulltextDocument(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/processFulltextDocument" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatal(err)
+		}
+		if r.FormValue("consolidateHeader") != "1" || r.FormValue("segmentSentences") != "1" {
+			t.Errorf("Unexpected form %v", r.MultipartForm.Value)
+		}
+		if coordinates := r.MultipartForm.Value["teiCoordinates"]; !reflect.DeepEqual(coordinates, []string{"s", "figure"}) {
+			t.Errorf("Unexpected teiCoordinates %v", coordinates)
+		}
+		if _, _, err := r.FormFile("input"); err != nil {
+			t.Errorf("Missing input file: %v", err)
+		}
+		w.Write([]byte("<TEI/>"))
+	}))
+	defer server.Close()
+
+	client := NewGrobidClient(GrobidConfig{BaseURL: server.URL + "/"})
+	opts := ProcessOptions{ConsolidateHeader: ConsolidateFull, SegmentSentences: true, TEICoordinates: []string{"s", "figure"}}
+	tei, err := client.ProcessFulltextDocument(context.Background(), []byte("%PDF-"), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(tei) != "<TEI/>" {
+		t.Errorf("Unexpected TEI %s", tei)
+	}
+}
+
+// test busy responses are retried and other errors are not
+func TestGrobidClient_Retries(t *testing.T) {
+	statuses := []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK}
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[calls%len(statuses)]
+		calls++
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client := NewGrobidClient(GrobidConfig{BaseURL: server.URL, Retries: 2})
+	if err := client.IsAlive(context.Background()); err != nil {
+		t.Errorf("Expected success after retrying, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+
+	statuses = []int{http.StatusInternalServerError}
+	calls = 0
+	_, err := client.ProcessCitation(context.Background(), "A. Author. A paper. 2020.", ProcessOptions{})
+	var grobidErr *GrobidError
+	if !errors.As(err, &grobidErr) || grobidErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected a GrobidError, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+}
+
+// test a process request whose attempt times out is not retried, while a timed out check is
+func TestGrobidClient_AttemptTimeout(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewGrobidClient(GrobidConfig{BaseURL: server.URL, Timeout: 50 * time.Millisecond, Retries: 2})
+	_, err := client.ProcessHeaderDocument(context.Background(), []byte("%PDF-"), ProcessOptions{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the attempt to time out, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected 1 call, got %d", calls.Load())
+	}
+
+	calls.Store(0)
+	if err := client.IsAlive(context.Background()); err == nil {
+		t.Errorf("Expected the check to time out")
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls.Load())
+	}
+}
+
+// test both formats of the version endpoint
+func TestGrobidClient_Version(t *testing.T) {
+	for body, want := range map[string]string{`{"version":"0.8.0","revision":"abc"}`: "0.8.0", "0.7.3\n": "0.7.3"} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(body))
+		}))
+		version, err := NewGrobidClient(GrobidConfig{BaseURL: server.URL}).Version(context.Background())
+		server.Close()
+		if err != nil || version != want {
+			t.Errorf("Expected %s, got %s (%v)", want, version, err)
+		}
+	}
+}
+
+// test a streamed PDF is opened again on each retry, and an oversize one fails without retrying
+func TestGrobidClient_ProcessFulltextStream(t *testing.T) {
+	statuses := []int{http.StatusServiceUnavailable, http.StatusOK}
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[calls%len(statuses)]
+		calls++
+		file, _, err := r.FormFile("input")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		content, _ := io.ReadAll(file)
+		if string(content) != "%PDF-1.7" {
+			t.Errorf("Unexpected PDF %q", content)
+		}
+		w.WriteHeader(status)
+		w.Write([]byte("<TEI/>"))
+	}))
+	defer server.Close()
+
+	opened := 0
+	pdf := func(ctx context.Context) (io.ReadCloser, error) {
+		opened++
+		return io.NopCloser(strings.NewReader("%PDF-1.7")), nil
+	}
+	client := NewGrobidClient(GrobidConfig{BaseURL: server.URL, Retries: 2})
+	tei, err := client.ProcessFulltextStream(context.Background(), pdf, FulltextOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(tei) != "<TEI/>" || opened != 2 {
+		t.Errorf("Unexpected TEI %s after opening the PDF %d times", tei, opened)
+	}
+
+	calls, opened = 0, 0
+	_, err = client.ProcessFulltextStream(context.Background(), LimitPDF(pdf, 4), FulltextOptions())
+	if !errors.Is(err, ErrPDFTooLarge) {
+		t.Errorf("Expected ErrPDFTooLarge, got %v", err)
+	}
+	if opened != 1 {
+		t.Errorf("Expected the PDF to be opened once, got %d", opened)
+	}
+}
+
+// test a PDF is hashed while it is streamed to Grobid, and not before it has been read to the end
+func TestGrobidClient_HashingPDF(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<TEI/>"))
+	}))
+	defer server.Close()
+
+	pdf := NewHashingPDF(BytesPDF([]byte("%PDF-1.7")))
+	if pdf.SHA256() != "" {
+		t.Errorf("Expected no hash before the PDF is read, got %s", pdf.SHA256())
+	}
+	client := NewGrobidClient(GrobidConfig{BaseURL: server.URL})
+	if _, err := client.ProcessFulltextStream(context.Background(), pdf.Open, FulltextOptions()); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte("%PDF-1.7"))
+	if want := hex.EncodeToString(sum[:]); pdf.SHA256() != want {
+		t.Errorf("Expected hash %s, got %s", want, pdf.SHA256())
+	}
+}
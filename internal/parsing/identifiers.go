@@ -0,0 +1,132 @@
+package parsing
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Identifiers are the identifiers of a paper, normalised so that the same paper always gets the same values
+type Identifiers struct {
+	// DOI is lower case, without a resolver prefix
+	DOI string `json:"doi"`
+	// ArXiv is the arXiv ID without its version, such as "2101.00001" or "hep-th/9901001"
+	ArXiv string `json:"arxiv"`
+	PMID  string `json:"pmid"`
+	// PMCID keeps its PMC prefix
+	PMCID string `json:"pmcid"`
+	// PII is Elsevier's publisher item identifier, upper case without punctuation: an S and 16 characters
+	PII string `json:"pii"`
+	// ISBN is its digits, with a final X for some ISBN-10s
+	ISBN string `json:"isbn"`
+}
+
+var (
+	doiRegex        = regexp.MustCompile(`\b(10\.[0-9]{4,}(?:\.[0-9]+)*/\S+)\b`)
+	arXivRegex      = regexp.MustCompile(`^(?i:arxiv:)?\s*(\d{4}\.\d{4,5}|[a-z\-]{1,16}(?:\.[A-Z]{2})?/\d{7})(?:v\d+)?$`)
+	arXivTextRegex  = regexp.MustCompile(`(?:(?i:arxiv):\s*|arxiv\.org/(?:abs|pdf)/)(\d{4}\.\d{4,5}|[a-z\-]{1,16}(?:\.[A-Z]{2})?/\d{7})`)
+	pmidRegex       = regexp.MustCompile(`^\d{1,9}$`)
+	pmcidRegex      = regexp.MustCompile(`(?i)^(?:pmc)?(\d{1,9})$`)
+	piiRegex        = regexp.MustCompile(`^S[0-9A-Z]{16}$`)
+	nonAlnumRegex   = regexp.MustCompile(`[^0-9A-Za-z]`)
+	isbnRegex       = regexp.MustCompile(`^(\d{9}[\dX]|\d{13})$`)
+	doiTrailingCuts = ".,;:)]}>\"'"
+)
+
+// TidyIdentifiers reads the idno elements of the header by their type attribute. An idno without a type is
+// taken as a DOI or an arXiv ID when it looks like one.
+func TidyIdentifiers(idnos []BiblIdnoRaw) Identifiers {
+	var ids Identifiers
+	set := func(field *string, value string) {
+		if *field == "" {
+			*field = value
+		}
+	}
+	for _, idno := range idnos {
+		value := strings.TrimSpace(idno.Value)
+		switch strings.ToLower(idno.Type) {
+		case "doi":
+			set(&ids.DOI, NormalizeDOI(value))
+		case "arxiv":
+			set(&ids.ArXiv, normalizeArXiv(value))
+		case "pmid":
+			if pmidRegex.MatchString(value) {
+				set(&ids.PMID, value)
+			}
+		case "pmcid":
+			if matches := pmcidRegex.FindStringSubmatch(value); matches != nil {
+				set(&ids.PMCID, "PMC"+matches[1])
+			}
+		case "pii":
+			if pii := strings.ToUpper(nonAlnumRegex.ReplaceAllString(value, "")); piiRegex.MatchString(pii) {
+				set(&ids.PII, pii)
+			}
+		case "isbn":
+			if isbn := strings.ToUpper(nonAlnumRegex.ReplaceAllString(value, "")); isbnRegex.MatchString(isbn) {
+				set(&ids.ISBN, isbn)
+			}
+		case "":
+			set(&ids.DOI, NormalizeDOI(value))
+			set(&ids.ArXiv, normalizeArXiv(value))
+		}
+	}
+	return ids
+}
+
+// Scan fills the DOI and arXiv ID from text when the header did not have them
+func (ids *Identifiers) Scan(text string) {
+	if ids.DOI == "" {
+		ids.DOI = NormalizeDOI(text)
+	}
+	if ids.ArXiv == "" {
+		if matches := arXivTextRegex.FindStringSubmatch(text); matches != nil {
+			ids.ArXiv = normalizeArXiv(matches[1])
+		}
+	}
+}
+
+// NormalizeDOI extracts the DOI of a value, such as a doi.org URL, dropping trailing punctuation, in lower case.
+// A DOI longer than the doi columns is dropped.
+func NormalizeDOI(value string) string {
+	doi := doiRegex.FindString(value)
+	return upTo(strings.ToLower(strings.TrimRight(doi, doiTrailingCuts)), maxNameLength)
+}
+
+func normalizeArXiv(value string) string {
+	matches := arXivRegex.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return ""
+	}
+	return matches[1]
+}
+
+// firstPageText gathers the text Grobid places on the first page, where publishers print the DOI of a paper:
+// the header notes, the abstract, page headers and the paragraphs on page 1. Without coordinates the first
+// section stands in for the paragraphs.
+func firstPageText(crude *CrudeGrobidResponse) string {
+	var texts []string
+	for _, note := range crude.Notes {
+		texts = append(texts, innerText(note.Text))
+	}
+	for _, note := range crude.BodyNotes {
+		if note.Place == "headnote" {
+			texts = append(texts, innerText(note.Text))
+		}
+	}
+	for _, p := range crude.Abstract.P {
+		texts = append(texts, p.Text)
+	}
+	for _, div := range crude.Abstract.Divs {
+		for _, p := range div.P {
+			texts = append(texts, p.Text)
+		}
+	}
+	for i, section := range crude.Sections {
+		for _, p := range section.P {
+			boxes := p.Boxes()
+			if (len(boxes) > 0 && boxes[0].Page == 1) || (len(boxes) == 0 && i == 0) {
+				texts = append(texts, p.Text)
+			}
+		}
+	}
+	return strings.Join(texts, "\n")
+}
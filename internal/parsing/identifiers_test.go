@@ -0,0 +1,97 @@
+package parsing
+
+import (
+	"strings"
+	"testing"
+)
+
+// test idno elements are read by their type and normalised
+func TestTidyIdentifiers(t *testing.T) {
+	ids := TidyIdentifiers([]BiblIdnoRaw{
+		{Type: "MD5", Value: "0E4E3D8A2B7C"},
+		{Type: "DOI", Value: "https://doi.org/10.1016/J.Chemosphere.2020.126."},
+		{Type: "arXiv", Value: "arXiv:2101.00001v2"},
+		{Type: "PMID", Value: " 32512345 "},
+		{Type: "PMCID", Value: "7281234"},
+		{Type: "PII", Value: "S0045-6535(20)30123-4"},
+		{Type: "ISBN", Value: "978-3-16-148410-0"},
+		{Type: "DOI", Value: "10.9999/second"},
+	})
+
+	want := Identifiers{
+		DOI:   "10.1016/j.chemosphere.2020.126",
+		ArXiv: "2101.00001",
+		PMID:  "32512345",
+		PMCID: "PMC7281234",
+		PII:   "S0045653520301234",
+		ISBN:  "9783161484100",
+	}
+	if ids != want {
+		t.Errorf("Unexpected identifiers: %+v", ids)
+	}
+}
+
+// test an idno without a type and invalid values, values that do not fit their columns included
+func TestTidyIdentifiersUntyped(t *testing.T) {
+	ids := TidyIdentifiers([]BiblIdnoRaw{
+		{Value: "hep-th/9901001v1"},
+		{Value: "doi:10.1000/xyz123"},
+		{Type: "PMID", Value: "PMC123"},
+		{Type: "ISBN", Value: "12345"},
+		{Type: "PII", Value: "see the publisher's site for the full text"},
+		{Type: "PII", Value: "S0045-6535(20)3012"},
+		{Type: "arXiv", Value: "not-an-archive-name-of-arxiv/9901001"},
+	})
+	if ids != (Identifiers{DOI: "10.1000/xyz123", ArXiv: "hep-th/9901001"}) {
+		t.Errorf("Unexpected identifiers: %+v", ids)
+	}
+}
+
+// test the DOI and arXiv ID are found on the first page when the header has none, and references are not scanned
+func TestFirstPageIdentifiers(t *testing.T) {
+	tei := `<?xml version="1.0" encoding="UTF-8"?>
+<TEI xmlns="http://www.tei-c.org/ns/1.0">
+	<teiHeader>
+		<fileDesc>
+			<titleStmt><title>A paper</title></titleStmt>
+			<sourceDesc><biblStruct><idno type="MD5">0E4E3D8A2B7C</idno></biblStruct></sourceDesc>
+		</fileDesc>
+	</teiHeader>
+	<text>
+		<body>
+			<note place="headnote">Preprint arXiv:2101.00001v3 [cs.CL]</note>
+			<div><head>Introduction</head><p coords="1,10,10,100,10">Published as https://doi.org/10.1000/ABC.123.</p></div>
+			<div><head>Methods</head><p coords="2,10,10,100,10">As in 10.9999/other.</p></div>
+		</body>
+		<back>
+			<div type="references"><listBibl><biblStruct><analytic><idno type="DOI">10.8888/cited</idno></analytic></biblStruct></listBibl></div>
+		</back>
+	</text>
+</TEI>`
+	crude, err := ParseGrobidResponse([]byte(tei))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tidy, err := TidyUpGrobidResponse(crude)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tidy.Doi != "10.1000/abc.123" || tidy.Identifiers.DOI != tidy.Doi || tidy.Identifiers.ArXiv != "2101.00001" {
+		t.Errorf("Unexpected identifiers: %q, %+v", tidy.Doi, tidy.Identifiers)
+	}
+}
+
+// test a PMID printed in the PDF becomes the PubMed ID
+func TestCreatePDFDTOPubMedID(t *testing.T) {
+	pdfDTO := CreatePDFDTO(&TidyGrobidResponse{Identifiers: Identifiers{PMID: "32512345"}}, nil)
+	if pdfDTO.PubMedID != 32512345 {
+		t.Errorf("Unexpected PubMed ID: %v", pdfDTO.PubMedID)
+	}
+}
+
+// test a DOI longer than the doi columns is dropped
+func TestNormalizeDOI_TooLong(t *testing.T) {
+	if doi := NormalizeDOI("10.1000/" + strings.Repeat("a", 300)); doi != "" {
+		t.Errorf("Expected no DOI, got %q", doi)
+	}
+}
@@ -0,0 +1,152 @@
+package parsing
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// GrobidOptions are the Grobid options a request or a screen may set, nil fields keep the defaults they are applied to
+type GrobidOptions struct {
+	ConsolidateHeader      *int  `json:"consolidate_header,omitempty"`
+	ConsolidateCitations   *int  `json:"consolidate_citations,omitempty"`
+	IncludeRawCitations    *bool `json:"include_raw_citations,omitempty"`
+	IncludeRawAffiliations *bool `json:"include_raw_affiliations,omitempty"`
+	SegmentSentences       *bool `json:"segment_sentences,omitempty"`
+	// TEICoordinates replaces the default elements, an empty list turns coordinates off
+	TEICoordinates *[]string `json:"tei_coordinates,omitempty"`
+	// Start and End are the first and last pages to process, counting from 1
+	Start *int `json:"start,omitempty"`
+	End   *int `json:"end,omitempty"`
+}
+
+// allowedTEICoordinates are the elements Grobid can add coordinates to
+var allowedTEICoordinates = map[string]bool{
+	"persName": true, "figure": true, "ref": true, "biblStruct": true, "formula": true, "s": true, "p": true,
+	"note": true, "title": true, "affiliation": true, "head": true,
+}
+
+// Validate checks the options against the values Grobid accepts
+func (o GrobidOptions) Validate() error {
+	for name, level := range map[string]*int{"consolidate_header": o.ConsolidateHeader, "consolidate_citations": o.ConsolidateCitations} {
+		if level != nil && (*level < ConsolidateNone || *level > ConsolidateDOIMerge) {
+			return fmt.Errorf("%s must be between %d and %d", name, ConsolidateNone, ConsolidateDOIMerge)
+		}
+	}
+	if o.TEICoordinates != nil {
+		for _, element := range *o.TEICoordinates {
+			if !allowedTEICoordinates[element] {
+				return fmt.Errorf("tei_coordinates cannot include %q", element)
+			}
+		}
+	}
+	if o.Start != nil && *o.Start < 1 {
+		return fmt.Errorf("start must be at least 1")
+	}
+	if o.End != nil && *o.End < 1 {
+		return fmt.Errorf("end must be at least 1")
+	}
+	if o.Start != nil && o.End != nil && *o.End < *o.Start {
+		return fmt.Errorf("end must not be before start")
+	}
+	return nil
+}
+
+// Apply returns base with the options that are set replaced
+func (o GrobidOptions) Apply(base ProcessOptions) ProcessOptions {
+	if o.ConsolidateHeader != nil {
+		base.ConsolidateHeader = *o.ConsolidateHeader
+	}
+	if o.ConsolidateCitations != nil {
+		base.ConsolidateCitations = *o.ConsolidateCitations
+	}
+	if o.IncludeRawCitations != nil {
+		base.IncludeRawCitations = *o.IncludeRawCitations
+	}
+	if o.IncludeRawAffiliations != nil {
+		base.IncludeRawAffiliations = *o.IncludeRawAffiliations
+	}
+	if o.SegmentSentences != nil {
+		base.SegmentSentences = *o.SegmentSentences
+	}
+	if o.TEICoordinates != nil {
+		base.TEICoordinates = append([]string{}, *o.TEICoordinates...)
+	}
+	if o.Start != nil {
+		base.Start = *o.Start
+	}
+	if o.End != nil {
+		base.End = *o.End
+	}
+	return base
+}
+
+// OptionsOf returns opts with every option set, the pages only when they are limited
+func OptionsOf(opts ProcessOptions) GrobidOptions {
+	coordinates := append([]string{}, opts.TEICoordinates...)
+	options := GrobidOptions{
+		ConsolidateHeader:      &opts.ConsolidateHeader,
+		ConsolidateCitations:   &opts.ConsolidateCitations,
+		IncludeRawCitations:    &opts.IncludeRawCitations,
+		IncludeRawAffiliations: &opts.IncludeRawAffiliations,
+		SegmentSentences:       &opts.SegmentSentences,
+		TEICoordinates:         &coordinates,
+	}
+	if opts.Start > 0 {
+		options.Start = &opts.Start
+	}
+	if opts.End > 0 {
+		options.End = &opts.End
+	}
+	return options
+}
+
+// ResolveOptions applies the options of a screen then those of a request over FulltextOptions, checking both
+func ResolveOptions(screen, request GrobidOptions) (ProcessOptions, error) {
+	if err := screen.Validate(); err != nil {
+		return ProcessOptions{}, fmt.Errorf("screen options: %w", err)
+	}
+	if err := request.Validate(); err != nil {
+		return ProcessOptions{}, err
+	}
+	opts := request.Apply(screen.Apply(FulltextOptions()))
+	if opts.Start > 0 && opts.End > 0 && opts.End < opts.Start {
+		return ProcessOptions{}, fmt.Errorf("end must not be before start")
+	}
+	return opts, nil
+}
+
+// ParseGrobidOptions reads options from form values, such as those sent to /parse, by their JSON names. Each element
+// of tei_coordinates is a value of its own, a single empty value turns coordinates off.
+func ParseGrobidOptions(form map[string][]string) (GrobidOptions, error) {
+	var opts GrobidOptions
+	ints := map[string]**int{"consolidate_header": &opts.ConsolidateHeader, "consolidate_citations": &opts.ConsolidateCitations, "start": &opts.Start, "end": &opts.End}
+	for name, field := range ints {
+		if values := form[name]; len(values) > 0 {
+			n, err := strconv.Atoi(values[0])
+			if err != nil {
+				return opts, fmt.Errorf("%s must be an integer", name)
+			}
+			*field = &n
+		}
+	}
+	bools := map[string]**bool{"include_raw_citations": &opts.IncludeRawCitations, "include_raw_affiliations": &opts.IncludeRawAffiliations, "segment_sentences": &opts.SegmentSentences}
+	for name, field := range bools {
+		if values := form[name]; len(values) > 0 {
+			b, err := strconv.ParseBool(values[0])
+			if err != nil {
+				return opts, fmt.Errorf("%s must be a boolean", name)
+			}
+			*field = &b
+		}
+	}
+	if values, ok := form["tei_coordinates"]; ok {
+		elements := []string{}
+		for _, value := range values {
+			if value != "" {
+				elements = append(elements, value)
+			}
+		}
+		opts.TEICoordinates = &elements
+	}
+	return opts, opts.Validate()
+}
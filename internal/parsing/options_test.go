@@ -0,0 +1,95 @@
+package parsing
+
+import (
+	"reflect"
+	"testing"
+)
+
+// test request options override screen options, which override FulltextOptions
+func TestResolveOptions(t *testing.T) {
+	full, none, page := ConsolidateFull, ConsolidateNone, 2
+	off := false
+	noCoordinates := []string{}
+	screen := GrobidOptions{ConsolidateCitations: &full, SegmentSentences: &off}
+	request := GrobidOptions{ConsolidateHeader: &none, TEICoordinates: &noCoordinates, Start: &page}
+
+	opts, err := ResolveOptions(screen, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ProcessOptions{
+		ConsolidateHeader:    ConsolidateNone,
+		ConsolidateCitations: ConsolidateFull,
+		IncludeRawCitations:  true,
+		TEICoordinates:       []string{},
+		Start:                2,
+	}
+	if !reflect.DeepEqual(opts, want) {
+		t.Errorf("Expected %+v, got %+v", want, opts)
+	}
+
+	opts, err = ResolveOptions(GrobidOptions{}, GrobidOptions{})
+	if err != nil || !reflect.DeepEqual(opts, FulltextOptions()) {
+		t.Errorf("Expected FulltextOptions, got %+v: %v", opts, err)
+	}
+}
+
+// test options outside the allow-list are rejected
+func TestResolveOptions_Invalid(t *testing.T) {
+	level, first, last := 4, 5, 3
+	table := []string{"s", "table"}
+	for name, opts := range map[string]GrobidOptions{
+		"level":       {ConsolidateHeader: &level},
+		"coordinates": {TEICoordinates: &table},
+		"pages":       {Start: &first, End: &last},
+	} {
+		if _, err := ResolveOptions(GrobidOptions{}, opts); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	// a request start after the screen's end
+	if _, err := ResolveOptions(GrobidOptions{End: &last}, GrobidOptions{Start: &first}); err == nil {
+		t.Error("Expected an error for pages out of order")
+	}
+}
+
+// test options read from form values
+func TestParseGrobidOptions(t *testing.T) {
+	opts, err := ParseGrobidOptions(map[string][]string{
+		"input":                 {"ignored"},
+		"consolidate_citations": {"1"},
+		"include_raw_citations": {"false"},
+		"tei_coordinates":       {"s", "figure"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.ConsolidateCitations == nil || *opts.ConsolidateCitations != 1 || opts.IncludeRawCitations == nil || *opts.IncludeRawCitations {
+		t.Errorf("Unexpected options %+v", opts)
+	}
+	if opts.TEICoordinates == nil || !reflect.DeepEqual(*opts.TEICoordinates, []string{"s", "figure"}) {
+		t.Errorf("Unexpected coordinates %v", opts.TEICoordinates)
+	}
+
+	if _, err := ParseGrobidOptions(map[string][]string{"start": {"one"}}); err == nil {
+		t.Error("Expected an error for a start that is not a number")
+	}
+}
+
+// test every option is set from the resolved ones, pages only when they are limited
+func TestOptionsOf(t *testing.T) {
+	opts := OptionsOf(FulltextOptions())
+	if opts.ConsolidateHeader == nil || *opts.ConsolidateHeader != ConsolidateFull || opts.IncludeRawAffiliations == nil || *opts.IncludeRawAffiliations {
+		t.Errorf("Unexpected options %+v", opts)
+	}
+	if opts.TEICoordinates == nil || !reflect.DeepEqual(*opts.TEICoordinates, FulltextOptions().TEICoordinates) {
+		t.Errorf("Unexpected coordinates %v", opts.TEICoordinates)
+	}
+	if opts.Start != nil || opts.End != nil {
+		t.Errorf("Expected no page limits, got %v and %v", opts.Start, opts.End)
+	}
+	if err := opts.Validate(); err != nil {
+		t.Errorf("Expected valid options: %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package parsing
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"sync"
+)
+
+// PDFOpener opens a PDF to send to Grobid. A retry opens it again, so a PDF can be streamed from where it is stored
+// instead of being held in memory.
+type PDFOpener func(ctx context.Context) (io.ReadCloser, error)
+
+// ErrPDFTooLarge is returned when a PDF is bigger than the limit given to LimitPDF
+var ErrPDFTooLarge = errors.New("pdf is too large")
+
+// BytesPDF opens a PDF held in memory
+func BytesPDF(pdf []byte) PDFOpener {
+	return func(ctx context.Context) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(pdf)), nil
+	}
+}
+
+// LimitPDF fails reading a PDF with ErrPDFTooLarge once more than maxBytes have been read, in case the PDF is
+// bigger than its size said
+func LimitPDF(open PDFOpener, maxBytes int64) PDFOpener {
+	return func(ctx context.Context) (io.ReadCloser, error) {
+		pdf, err := open(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &limitedPDF{ReadCloser: pdf, remaining: maxBytes}, nil
+	}
+}
+
+type limitedPDF struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (l *limitedPDF) Read(p []byte) (int, error) {
+	n, err := l.ReadCloser.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, ErrPDFTooLarge
+	}
+	return n, err
+}
+
+// HashingPDF hashes a PDF as it is read, so a PDF streamed to Grobid does not have to be read again to be hashed
+type HashingPDF struct {
+	open PDFOpener
+
+	mu     sync.Mutex
+	sha256 string
+}
+
+// NewHashingPDF hashes the PDF open opens
+func NewHashingPDF(open PDFOpener) *HashingPDF {
+	return &HashingPDF{open: open}
+}
+
+// Open opens the PDF, recording its SHA-256 once it has been read to the end
+func (h *HashingPDF) Open(ctx context.Context) (io.ReadCloser, error) {
+	pdf, err := h.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.New()
+	return &hashingReader{tee: io.TeeReader(pdf, hash), Closer: pdf, hash: hash, pdf: h}, nil
+}
+
+// SHA256 returns the hex SHA-256 of the PDF, or "" when it has not been read to the end yet
+func (h *HashingPDF) SHA256() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.sha256
+}
+
+type hashingReader struct {
+	io.Closer
+	tee  io.Reader
+	hash hash.Hash
+	pdf  *HashingPDF
+}
+
+func (r *hashingReader) Read(p []byte) (int, error) {
+	n, err := r.tee.Read(p)
+	if err == io.EOF {
+		r.pdf.mu.Lock()
+		r.pdf.sha256 = hex.EncodeToString(r.hash.Sum(nil))
+		r.pdf.mu.Unlock()
+	}
+	return n, err
+}
+
+// pdfReadError is a failure to read the PDF while sending it, which retrying the request will not fix
+type pdfReadError struct {
+	err error
+}
+
+func (e *pdfReadError) Error() string {
+	return "reading pdf: " + e.err.Error()
+}
+
+func (e *pdfReadError) Unwrap() error {
+	return e.err
+}
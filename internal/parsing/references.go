@@ -0,0 +1,180 @@
+package parsing
+
+import (
+	"strings"
+)
+
+// BiblStructRaw is an entry of the bibliography Grobid extracts into text>back
+type BiblStructRaw struct {
+	ID       string        `xml:"http://www.w3.org/XML/1998/namespace id,attr"`
+	Coords   string        `xml:"coords,attr"`
+	Analytic BiblLevelRaw  `xml:"analytic"`
+	Monogr   BiblLevelRaw  `xml:"monogr"`
+	Notes    []BiblNoteRaw `xml:"note"`
+}
+
+// BiblLevelRaw is the analytic (article) or monogr (journal, book) part of a biblStruct
+type BiblLevelRaw struct {
+	Titles    []BiblTitleRaw `xml:"title"`
+	Authors   []PersNameRaw  `xml:"author>persName"`
+	IDNOs     []BiblIdnoRaw  `xml:"idno"`
+	Date      BiblDateRaw    `xml:"imprint>date"`
+	Publisher string         `xml:"imprint>publisher"`
+	Scopes    []BiblScopeRaw `xml:"imprint>biblScope"`
+}
+
+type BiblTitleRaw struct {
+	Level string `xml:"level,attr"`
+	Type  string `xml:"type,attr"`
+	Text  string `xml:",chardata"`
+}
+
+// BiblScopeRaw is a volume, issue or page range, pages come as from and to attributes
+type BiblScopeRaw struct {
+	Unit string `xml:"unit,attr"`
+	From string `xml:"from,attr"`
+	To   string `xml:"to,attr"`
+	Text string `xml:",chardata"`
+}
+
+type PersNameRaw struct {
+	Forenames []string `xml:"forename"`
+	Surname   string   `xml:"surname"`
+}
+
+type BiblIdnoRaw struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type BiblDateRaw struct {
+	When string `xml:"when,attr"`
+	Text string `xml:",chardata"`
+}
+
+type BiblNoteRaw struct {
+	Type string `xml:"type,attr"`
+	// Place is where a note of the body was printed, such as headnote for page headers
+	Place string `xml:"place,attr"`
+	Text  string `xml:",innerxml"`
+}
+
+// Reference is a bibliography entry of a paper
+type Reference struct {
+	// TEIID is the xml:id of the biblStruct, which the inline citations in the body point to
+	TEIID   string   `json:"tei_id"`
+	Title   string   `json:"title"`
+	Authors []string `json:"authors"`
+	Year    string   `json:"year"`
+	Venue   string   `json:"venue"`
+	DOI     string   `json:"doi"`
+	// Raw is the citation as printed, only present when Grobid is asked to include raw citations
+	Raw    string `json:"raw"`
+	Coords []Box  `json:"coords"`
+}
+
+// TidyReferences turns the bibliography of a TEI document into references, skipping empty entries
+func TidyReferences(bibl []BiblStructRaw) []Reference {
+	var references []Reference
+	for _, entry := range bibl {
+		reference := Reference{
+			TEIID:  entry.ID,
+			Title:  entry.Analytic.title(),
+			DOI:    NormalizeDOI(entry.Analytic.idno("DOI")),
+			Year:   entry.Monogr.year(),
+			Coords: ParseCoords(entry.Coords),
+		}
+
+		// a book or a report has no analytic level, its title and authors are in monogr
+		authors := entry.Analytic.Authors
+		if reference.Title == "" {
+			reference.Title = entry.Monogr.title()
+		} else {
+			reference.Venue = entry.Monogr.title()
+		}
+		if len(authors) == 0 {
+			authors = entry.Monogr.Authors
+		}
+		for _, author := range authors {
+			if name := author.name(); name != "" {
+				reference.Authors = append(reference.Authors, name)
+			}
+		}
+		if reference.DOI == "" {
+			reference.DOI = NormalizeDOI(entry.Monogr.idno("DOI"))
+		}
+		for _, note := range entry.Notes {
+			if note.Type == "raw_reference" {
+				reference.Raw = collapseSpace(note.Text)
+			}
+		}
+
+		if reference.Title == "" && reference.Raw == "" && len(reference.Authors) == 0 {
+			continue
+		}
+		references = append(references, reference)
+	}
+	return references
+}
+
+// title prefers the main title over subtitles and abbreviations
+func (l BiblLevelRaw) title() string {
+	for _, title := range l.Titles {
+		if title.Type == "main" || title.Type == "" {
+			return collapseSpace(title.Text)
+		}
+	}
+	if len(l.Titles) > 0 {
+		return collapseSpace(l.Titles[0].Text)
+	}
+	return ""
+}
+
+// abbreviatedTitle is the abbreviated journal title, such as "J Appl Toxicol"
+func (l BiblLevelRaw) abbreviatedTitle() string {
+	for _, title := range l.Titles {
+		if title.Type == "abbrev" {
+			return collapseSpace(title.Text)
+		}
+	}
+	return ""
+}
+
+// scope returns the volume, issue or pages, a page range as "from-to"
+func (l BiblLevelRaw) scope(unit string) string {
+	for _, scope := range l.Scopes {
+		if scope.Unit != unit {
+			continue
+		}
+		from, to := strings.TrimSpace(scope.From), strings.TrimSpace(scope.To)
+		switch {
+		case from != "" && to != "" && from != to:
+			return from + "-" + to
+		case from != "":
+			return from
+		}
+		return collapseSpace(scope.Text)
+	}
+	return ""
+}
+
+func (l BiblLevelRaw) idno(idType string) string {
+	for _, idno := range l.IDNOs {
+		if strings.EqualFold(idno.Type, idType) {
+			return strings.TrimSpace(idno.Value)
+		}
+	}
+	return ""
+}
+
+func (l BiblLevelRaw) year() string {
+	return ParseDate(l.Date.When, l.Date.Text).YearString()
+}
+
+func (n PersNameRaw) name() string {
+	return collapseSpace(strings.Join(append(n.Forenames, n.Surname), " "))
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,75 @@
+package parsing
+
+import (
+	"reflect"
+	"testing"
+)
+
+const bibliographyTEI = `<?xml version="1.0" encoding="UTF-8"?>
+<TEI xmlns="http://www.tei-c.org/ns/1.0">
+	<teiHeader>
+		<fileDesc>
+			<titleStmt><title>A paper</title></titleStmt>
+			<sourceDesc><biblStruct><idno type="DOI">10.1000/paper</idno></biblStruct></sourceDesc>
+		</fileDesc>
+	</teiHeader>
+	<text>
+		<back>
+			<div type="references">
+				<listBibl>
+					<biblStruct xml:id="b0">
+						<analytic>
+							<title level="a" type="main">Tebuconazole alters
+								zebrafish behaviour</title>
+							<author><persName><forename type="first">Ana</forename><forename type="middle">M</forename><surname>Silva</surname></persName></author>
+							<author><persName><forename type="first">João</forename><surname>Costa</surname></persName></author>
+							<idno type="DOI">10.1016/j.chemosphere.2017.04.029</idno>
+						</analytic>
+						<monogr>
+							<title level="j">Chemosphere</title>
+							<imprint><date type="published" when="2017-08">August 2017</date></imprint>
+						</monogr>
+						<note type="raw_reference">Silva AM, Costa J. Tebuconazole alters zebrafish behaviour. Chemosphere. 2017.</note>
+					</biblStruct>
+					<biblStruct xml:id="b1">
+						<monogr>
+							<title level="m">Systematic Reviews in Health Care</title>
+							<author><persName><forename type="first">Matthias</forename><surname>Egger</surname></persName></author>
+							<imprint><date>2001</date></imprint>
+						</monogr>
+					</biblStruct>
+					<biblStruct xml:id="b2"><monogr><imprint/></monogr></biblStruct>
+				</listBibl>
+			</div>
+		</back>
+	</text>
+</TEI>`
+
+// test the bibliography is read from the back of the TEI
+func TestTidyReferences(t *testing.T) {
+	crude, err := ParseGrobidResponse([]byte(bibliographyTEI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Reference{
+		{
+			TEIID:   "b0",
+			Title:   "Tebuconazole alters zebrafish behaviour",
+			Authors: []string{"Ana M Silva", "João Costa"},
+			Year:    "2017",
+			Venue:   "Chemosphere",
+			DOI:     "10.1016/j.chemosphere.2017.04.029",
+			Raw:     "Silva AM, Costa J. Tebuconazole alters zebrafish behaviour. Chemosphere. 2017.",
+		},
+		{
+			TEIID:   "b1",
+			Title:   "Systematic Reviews in Health Care",
+			Authors: []string{"Matthias Egger"},
+			Year:    "2001",
+		},
+	}
+	if references := TidyReferences(crude.References); !reflect.DeepEqual(references, want) {
+		t.Errorf("Unexpected references: %+v", references)
+	}
+}
@@ -0,0 +1,75 @@
+package parsing
+
+import (
+	"strings"
+)
+
+type HeadRaw struct {
+	N    string `xml:"n,attr"`
+	Text string `xml:",chardata"`
+}
+
+// Section is a section of a paper's body, placed in the section tree
+type Section struct {
+	Head string `json:"head"`
+	// Number is the printed section number, such as "2.3.1"
+	Number string `json:"number"`
+	// Depth is 1 for top level sections
+	Depth int `json:"depth"`
+	// Parent is the index of the enclosing section in the list, -1 for top level sections
+	Parent     int            `json:"parent"`
+	Paragraphs []ParagraphRaw `json:"paragraphs"`
+}
+
+// TidySections flattens the body into sections in document order, each pointing to its parent.
+// Grobid rarely nests divs, so the depth comes from the section number when there is one and from the nesting otherwise.
+func TidySections(raw []SectionRaw) []Section {
+	var sections []Section
+	// open holds the indexes of the sections that can still enclose the next one, innermost last
+	var open []int
+	var walk func(divs []SectionRaw, nesting int)
+	walk = func(divs []SectionRaw, nesting int) {
+		for _, div := range divs {
+			section := Section{
+				Head:       collapseSpace(div.Head.Text),
+				Number:     strings.TrimSuffix(strings.TrimSpace(div.Head.N), "."),
+				Depth:      nesting,
+				Parent:     -1,
+				Paragraphs: div.P,
+			}
+			if section.Number != "" {
+				section.Depth = strings.Count(section.Number, ".") + 1
+			}
+			// a div without a head continues the section before it, such as after a figure, and cannot enclose others
+			if section.Head == "" && section.Number == "" && len(sections) > 0 {
+				previous := sections[len(sections)-1]
+				section.Depth, section.Parent = previous.Depth, previous.Parent
+				sections = append(sections, section)
+				walk(div.Divs, section.Depth+1)
+				continue
+			}
+
+			for len(open) > 0 && sections[open[len(open)-1]].Depth >= section.Depth {
+				open = open[:len(open)-1]
+			}
+			if len(open) > 0 {
+				section.Parent = open[len(open)-1]
+			}
+			sections = append(sections, section)
+			open = append(open, len(sections)-1)
+
+			walk(div.Divs, section.Depth+1)
+		}
+	}
+	walk(raw, 1)
+	return sections
+}
+
+// Citation is an inline citation marker, such as "[12]", taken out of the text of a paragraph
+type Citation struct {
+	// Offset is where the marker was in the paragraph text, in characters
+	Offset int    `json:"offset"`
+	Marker string `json:"marker"`
+	// Target is the TEI id of the cited reference, empty when Grobid could not match the marker to the bibliography
+	Target string `json:"target"`
+}
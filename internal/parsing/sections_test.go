@@ -0,0 +1,95 @@
+package parsing
+
+import (
+	"reflect"
+	"testing"
+)
+
+const sectionsTEI = `<?xml version="1.0" encoding="UTF-8"?>
+<TEI xmlns="http://www.tei-c.org/ns/1.0">
+	<text>
+		<body>
+			<div><head n="1.">Introduction</head><p>Intro.</p></div>
+			<div><head n="2.">Methods</head></div>
+			<div><head n="2.1.">Animals</head><p>Zebrafish.</p></div>
+			<div><p>Continued after a figure.</p></div>
+			<div><head n="2.3.1">Statistical analysis</head><p>ANOVA.</p></div>
+			<div><head>Results</head><p>Results.</p>
+				<div><head>Survival</head><p>Survival.</p></div>
+			</div>
+			<div><head n="3">Discussion</head><p>Discussion.</p></div>
+		</body>
+	</text>
+</TEI>`
+
+// test the section tree is rebuilt from section numbers and nesting
+func TestTidySections(t *testing.T) {
+	crude, err := ParseGrobidResponse([]byte(sectionsTEI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sections := TidySections(crude.Sections)
+
+	want := []struct {
+		head   string
+		number string
+		depth  int
+		parent int
+	}{
+		{"Introduction", "1", 1, -1},
+		{"Methods", "2", 1, -1},
+		{"Animals", "2.1", 2, 1},
+		{"", "", 2, 1},
+		{"Statistical analysis", "2.3.1", 3, 2},
+		{"Results", "", 1, -1},
+		{"Survival", "", 2, 5},
+		{"Discussion", "3", 1, -1},
+	}
+	if len(sections) != len(want) {
+		t.Fatalf("Expected %d sections, got %+v", len(want), sections)
+	}
+	for i, w := range want {
+		s := sections[i]
+		if s.Head != w.head || s.Number != w.number || s.Depth != w.depth || s.Parent != w.parent {
+			t.Errorf("Section %d: expected %+v, got %+v", i, w, s)
+		}
+	}
+}
+
+const citationsTEI = `<?xml version="1.0" encoding="UTF-8"?>
+<TEI xmlns="http://www.tei-c.org/ns/1.0">
+	<text>
+		<body>
+			<div>
+				<head>Introduction</head>
+				<p><s>Azoles are fungicides <ref type="bibr" target="#b0">[1]</ref>.</s><s>They reach rivers.</s></p>
+				<p>Figures are not citations <ref type="figure" target="#fig_0">(Fig. 1)</ref>.</p>
+				<p>An unsegmented paragraph <ref type="bibr">[2]</ref>.</p>
+			</div>
+		</body>
+	</text>
+</TEI>`
+
+// test citation markers are taken out of the text with their offset and target
+func TestParagraphCitations(t *testing.T) {
+	crude, err := ParseGrobidResponse([]byte(citationsTEI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	paragraphs := crude.Sections[0].P
+
+	want := []Citation{{Offset: 22, Marker: "[1]", Target: "b0"}}
+	if !reflect.DeepEqual(paragraphs[0].Citations, want) {
+		t.Errorf("Unexpected citations %+v", paragraphs[0].Citations)
+	}
+	if []rune(paragraphs[0].Text)[22] != '.' {
+		t.Errorf("Offset does not point after the cited words in %q", paragraphs[0].Text)
+	}
+	if len(paragraphs[1].Citations) != 0 {
+		t.Errorf("Expected no citations, got %+v", paragraphs[1].Citations)
+	}
+	want = []Citation{{Offset: 25, Marker: "[2]"}}
+	if !reflect.DeepEqual(paragraphs[2].Citations, want) {
+		t.Errorf("Unexpected citations %+v", paragraphs[2].Citations)
+	}
+}
@@ -2,23 +2,33 @@ package parsing
 
 import (
 	"log"
+	"strconv"
 	"strings"
 )
 
 type PDFDTO struct {
-	Title     string       `json:"title"`
-	DOI       string       `json:"doi"`
-	CustomKey string       `json:"custom_key"`
-	ISSN      string       `json:"issn"`
-	Abstract  string       `json:"abstract"`
-	Sections  []SectionRaw `json:"sections"`
-	Keywords  []string     `json:"keywords"`
-	Authors   []AuthorsRaw `json:"authors"`
-	Year      string       `json:"year"`
-	Journal   string       `json:"journal"`
-	Notes     string       `json:"notes"`
-	Date      string       `json:"date"`
-	PubMedID  any          `json:"pubmed_id"`
+	Title string `json:"title"`
+	DOI   string `json:"doi"`
+	// Identifiers are all the identifiers of the paper, their DOI is the same as DOI
+	Identifiers Identifiers `json:"identifiers"`
+	CustomKey   string      `json:"custom_key"`
+	ISSN        string      `json:"issn"`
+	Abstract    string      `json:"abstract"`
+	// AbstractParts are the parts Grobid found, even when the abstract came from Crossref
+	AbstractParts []AbstractPart `json:"abstract_parts"`
+	Sections      []Section      `json:"sections"`
+	Keywords      []string       `json:"keywords"`
+	Authors       []Author       `json:"authors"`
+	Year          string         `json:"year"`
+	Journal       string         `json:"journal"`
+	Notes         string         `json:"notes"`
+	Date          string         `json:"date"`
+	// PublishedDate is nil when the date could not be read
+	PublishedDate *PublishedDate `json:"published_date"`
+	PubMedID      any            `json:"pubmed_id"`
+	References    []Reference    `json:"references"`
+	Figures       []Figure       `json:"figures"`
+	Venue
 }
 
 // create a PDFDTO
@@ -38,6 +48,10 @@ func CreatePDFDTO(tidyGrobidResponse *TidyGrobidResponse, tidyCrossRefResponse *
 		if tidyCrossRefResponse.Year != "" {
 			log.Println("Using crossref year")
 			tidyGrobidResponse.Year = tidyCrossRefResponse.Year
+			// a date from another year would contradict it
+			if tidyGrobidResponse.PublishedDate.YearString() != tidyCrossRefResponse.Year {
+				tidyGrobidResponse.PublishedDate = nil
+			}
 		}
 		if tidyCrossRefResponse.ISSN != "" {
 			log.Println("Using crossref ISSN")
@@ -45,7 +59,8 @@ func CreatePDFDTO(tidyGrobidResponse *TidyGrobidResponse, tidyCrossRefResponse *
 		}
 		if tidyCrossRefResponse.DOI != "" {
 			log.Println("Using crossref DOI")
-			tidyGrobidResponse.Doi = tidyCrossRefResponse.DOI
+			tidyGrobidResponse.Doi = NormalizeDOI(tidyCrossRefResponse.DOI)
+			tidyGrobidResponse.Identifiers.DOI = tidyGrobidResponse.Doi
 		}
 	}
 
@@ -56,17 +71,28 @@ func CreatePDFDTO(tidyGrobidResponse *TidyGrobidResponse, tidyCrossRefResponse *
 	// trim abstract
 	tidyGrobidResponse.Abstract = strings.TrimSpace(tidyGrobidResponse.Abstract)
 
-	return &PDFDTO{
-		Title:    tidyGrobidResponse.Title,
-		DOI:      tidyGrobidResponse.Doi,
-		ISSN:     tidyGrobidResponse.ISSN,
-		Abstract: tidyGrobidResponse.Abstract,
-		Sections: tidyGrobidResponse.Sections,
-		Keywords: tidyGrobidResponse.Keywords,
-		Authors:  tidyGrobidResponse.Authors,
-		Year:     tidyGrobidResponse.Year,
-		Date:     tidyGrobidResponse.Date,
-		Journal:  tidyGrobidResponse.Journal,
-		Notes:    tidyGrobidResponse.Notes,
+	pdfDTO := &PDFDTO{
+		Title:         tidyGrobidResponse.Title,
+		DOI:           tidyGrobidResponse.Doi,
+		Identifiers:   tidyGrobidResponse.Identifiers,
+		ISSN:          tidyGrobidResponse.ISSN,
+		Abstract:      tidyGrobidResponse.Abstract,
+		AbstractParts: tidyGrobidResponse.AbstractParts,
+		Sections:      tidyGrobidResponse.Sections,
+		Keywords:      tidyGrobidResponse.Keywords,
+		Authors:       tidyGrobidResponse.Authors,
+		Year:          tidyGrobidResponse.Year,
+		Date:          tidyGrobidResponse.Date,
+		PublishedDate: tidyGrobidResponse.PublishedDate,
+		Journal:       tidyGrobidResponse.Journal,
+		Venue:         tidyGrobidResponse.Venue,
+		Notes:         tidyGrobidResponse.Notes,
+		References:    tidyGrobidResponse.References,
+		Figures:       tidyGrobidResponse.Figures,
 	}
+	// a PMID in the PDF saves looking it up
+	if pmid, err := strconv.Atoi(pdfDTO.Identifiers.PMID); err == nil {
+		pdfDTO.PubMedID = pmid
+	}
+	return pdfDTO
 }
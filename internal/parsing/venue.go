@@ -0,0 +1,44 @@
+package parsing
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Venue is where a paper was published, beyond the journal and ISSN that PDFDTO always had
+type Venue struct {
+	JournalAbbreviation string `json:"journal_abbreviation"`
+	EISSN               string `json:"eissn"`
+	Volume              string `json:"volume"`
+	Issue               string `json:"issue"`
+	// Pages is a page range such as "781-793", or the article number
+	Pages     string `json:"pages"`
+	Publisher string `json:"publisher"`
+}
+
+// Lengths of the papers columns venue fields are stored in, a longer value is not a venue field Grobid read right
+const (
+	maxScopeLength = 32
+	maxNameLength  = 255
+)
+
+var issnRegex = regexp.MustCompile(`\b(\d{4})-?(\d{3}[\dXx])\b`)
+
+// normalizeISSN returns an ISSN as "1234-567X", dropping what Grobid kept around it such as "(Online)", or ""
+// when value has no ISSN
+func normalizeISSN(value string) string {
+	matches := issnRegex.FindStringSubmatch(value)
+	if matches == nil {
+		return ""
+	}
+	return matches[1] + "-" + strings.ToUpper(matches[2])
+}
+
+// upTo returns value, or "" when it is longer than max characters
+func upTo(value string, max int) string {
+	if utf8.RuneCountInString(value) > max {
+		return ""
+	}
+	return value
+}
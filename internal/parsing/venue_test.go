@@ -0,0 +1,111 @@
+package parsing
+
+import (
+	"strings"
+	"testing"
+)
+
+const venueTEI = `<?xml version="1.0" encoding="UTF-8"?>
+<TEI xmlns="http://www.tei-c.org/ns/1.0">
+	<teiHeader>
+		<fileDesc>
+			<titleStmt><title>A paper</title></titleStmt>
+			<publicationStmt><publisher>Elsevier BV</publisher></publicationStmt>
+			<sourceDesc>
+				<biblStruct>
+					<analytic><title level="a" type="main">A paper</title></analytic>
+					<monogr>
+						<title level="j" type="main">Chemosphere</title>
+						<title level="j" type="abbrev">Chemosphere</title>
+						<idno type="pISSN">0045-6535</idno>
+						<idno type="eISSN">1879-1298</idno>
+						<imprint>
+							<publisher>Elsevier BV</publisher>
+							<biblScope unit="volume">251</biblScope>
+							<biblScope unit="issue">3</biblScope>
+							<biblScope unit="page" from="126" to="135" />
+							<date type="published" when="2020-07-04">4 July 2020</date>
+						</imprint>
+					</monogr>
+					<idno type="DOI">10.1016/j.chemosphere.2020.126</idno>
+				</biblStruct>
+			</sourceDesc>
+			<notesStmt>
+				<note type="raw_reference">Chemosphere 251 (2020) 126-135</note>
+				<note>Received <hi>1 May 2020</hi></note>
+			</notesStmt>
+		</fileDesc>
+	</teiHeader>
+</TEI>`
+
+// test the journal, ISSNs, volume, issue, pages, publisher and date come from the monograph block
+func TestTidyVenue(t *testing.T) {
+	crude, err := ParseGrobidResponse([]byte(venueTEI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tidy, err := TidyUpGrobidResponse(crude)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tidy.Journal != "Chemosphere" || tidy.ISSN != "0045-6535" {
+		t.Errorf("Unexpected journal %q and ISSN %q", tidy.Journal, tidy.ISSN)
+	}
+	want := Venue{
+		JournalAbbreviation: "Chemosphere",
+		EISSN:               "1879-1298",
+		Volume:              "251",
+		Issue:               "3",
+		Pages:               "126-135",
+		Publisher:           "Elsevier BV",
+	}
+	if tidy.Venue != want {
+		t.Errorf("Unexpected venue: %+v", tidy.Venue)
+	}
+	// the publication statement has no date, so it is read from the imprint
+	if tidy.Date != "4 July 2020" || tidy.PublishedDate == nil || *tidy.PublishedDate != (PublishedDate{Year: 2020, Month: 7, Day: 4, Precision: DatePrecisionDay}) {
+		t.Errorf("Unexpected date %q: %+v", tidy.Date, tidy.PublishedDate)
+	}
+	if tidy.Notes != "Chemosphere 251 (2020) 126-135\nReceived 1 May 2020" {
+		t.Errorf("Unexpected notes: %q", tidy.Notes)
+	}
+}
+
+// test ISSNs are read out of what Grobid kept around them, and venue fields too long for their columns dropped
+func TestTidyVenue_Normalised(t *testing.T) {
+	tei := strings.NewReplacer(
+		`<idno type="eISSN">1879-1298</idno>`, `<idno type="eISSN">1879129x (Online)</idno>`,
+		`<biblScope unit="issue">3</biblScope>`, `<biblScope unit="issue">`+strings.Repeat("3 ", 40)+`</biblScope>`,
+	).Replace(venueTEI)
+	crude, err := ParseGrobidResponse([]byte(tei))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tidy, err := TidyUpGrobidResponse(crude)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tidy.Venue.EISSN != "1879-129X" || tidy.ISSN != "0045-6535" {
+		t.Errorf("Unexpected ISSNs %q and %q", tidy.ISSN, tidy.Venue.EISSN)
+	}
+	if tidy.Venue.Issue != "" || tidy.Venue.Volume != "251" {
+		t.Errorf("Expected only the over-long issue to be dropped, got %+v", tidy.Venue)
+	}
+}
+
+// test an ISSN is found whatever surrounds it
+func TestNormalizeISSN(t *testing.T) {
+	for value, want := range map[string]string{
+		"0045-6535":           "0045-6535",
+		"00456535":            "0045-6535",
+		"ISSN 1879-129x":      "1879-129X",
+		"1234-5678 (Online)":  "1234-5678",
+		"not an issn":         "",
+		"123456789012 digits": "",
+	} {
+		if got := normalizeISSN(value); got != want {
+			t.Errorf("%q: expected %q, got %q", value, want, got)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package store
+
+import (
+	"database/sql"
+	"simple-go-app/internal/parsing"
+
+	"github.com/uniplaces/carbon"
+)
+
+// AbstractPart is a row of the abstract_parts table, a labelled part of a paper's abstract
+type AbstractPart struct {
+	ID        int64   `json:"id"`
+	PaperID   int64   `json:"paper_id"`
+	Order     int64   `json:"order"`
+	Label     *string `json:"label,omitempty"`
+	Text      string  `json:"text"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+const abstractPartColumns = "id, paper_id, `order`, label, text, created_at, updated_at"
+
+func scanAbstractPart(row rowScanner) (AbstractPart, error) {
+	var part AbstractPart
+	err := row.Scan(&part.ID, &part.PaperID, &part.Order, &part.Label, &part.Text, &part.CreatedAt, &part.UpdatedAt)
+	return part, err
+}
+
+// ReplaceAbstractParts swaps the parts of a paper's abstract for a new extraction in one transaction
+func (store *Store) ReplaceAbstractParts(paperID int64, parts []parsing.AbstractPart) error {
+	return store.inTx(func(tx *sql.Tx) error {
+		return replaceAbstractParts(tx, paperID, parts)
+	})
+}
+
+func replaceAbstractParts(tx *sql.Tx, paperID int64, parts []parsing.AbstractPart) error {
+	if _, err := tx.Exec("DELETE FROM abstract_parts WHERE paper_id = ?", paperID); err != nil {
+		return err
+	}
+	now := carbon.Now().DateTimeString()
+	for order, part := range parts {
+		_, err := tx.Exec("INSERT INTO abstract_parts (paper_id, `order`, label, text, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)",
+			paperID, order, nullString(part.Label), part.Text, now, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindAbstractPartsByPaper returns the parts of a paper's abstract in order
+func (store *Store) FindAbstractPartsByPaper(paperID int64) ([]AbstractPart, error) {
+	rows, err := store.db.Query("SELECT "+abstractPartColumns+" FROM abstract_parts WHERE paper_id = ? ORDER BY `order`", paperID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	parts := []AbstractPart{}
+	for rows.Next() {
+		part, err := scanAbstractPart(rows)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+	return parts, rows.Err()
+}
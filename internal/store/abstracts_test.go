@@ -0,0 +1,47 @@
+package store
+
+import (
+	"errors"
+	"simple-go-app/internal/parsing"
+	"testing"
+)
+
+// test the parts of an abstract are deleted and inserted again in order, unlabelled parts without a label
+func TestStore_ReplaceAbstractParts(t *testing.T) {
+	s, fake := newFakeStore(t)
+
+	err := s.ReplaceAbstractParts(5, []parsing.AbstractPart{{Text: "Zebrafish are a model."}, {Label: "Methods", Text: "Larvae were exposed."}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if deletes := fake.matching("DELETE FROM abstract_parts"); len(deletes) != 1 || deletes[0].args[0] != int64(5) {
+		t.Errorf("Expected the paper's abstract parts to be deleted, got %+v", deletes)
+	}
+	inserts := fake.matching("INSERT INTO abstract_parts")
+	if len(inserts) != 2 {
+		t.Fatalf("Expected 2 inserts, got %d", len(inserts))
+	}
+	if first := inserts[0].args; first[1] != int64(0) || first[2] != nil || first[3] != "Zebrafish are a model." {
+		t.Errorf("Unexpected first part %v", first)
+	}
+	if second := inserts[1].args; second[1] != int64(1) || second[2] != "Methods" {
+		t.Errorf("Unexpected second part %v", second)
+	}
+	if !fake.committed {
+		t.Errorf("Expected the transaction to be committed")
+	}
+}
+
+// test a failed delete leaves the existing parts in place
+func TestStore_ReplaceAbstractParts_Rollback(t *testing.T) {
+	s, fake := newFakeStore(t)
+	fake.fail("DELETE FROM abstract_parts", errors.New("lock wait timeout"))
+
+	if err := s.ReplaceAbstractParts(5, []parsing.AbstractPart{{Text: "Zebrafish."}}); err == nil {
+		t.Fatal("Expected the delete error")
+	}
+	if len(fake.matching("INSERT INTO abstract_parts")) != 0 || fake.committed {
+		t.Errorf("Expected nothing to be inserted or committed")
+	}
+}
@@ -0,0 +1,145 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"simple-go-app/internal/parsing"
+
+	"github.com/uniplaces/carbon"
+)
+
+// PaperAuthor is an author of a paper, a row of authors joined with its paper_authors row
+type PaperAuthor struct {
+	AuthorID      int64   `json:"author_id"`
+	Order         int64   `json:"order"`
+	Forename      *string `json:"forename,omitempty"`
+	Surname       *string `json:"surname,omitempty"`
+	Email         *string `json:"email,omitempty"`
+	ORCID         *string `json:"orcid,omitempty"`
+	Corresponding bool    `json:"corresponding"`
+	Affiliations  *string `json:"-"` // a JSON array, see AffiliationList
+}
+
+const paperAuthorColumns = "authors.id, paper_authors.`order`, authors.forename, authors.surname, authors.email, authors.orcid, paper_authors.corresponding, paper_authors.affiliations"
+
+func scanPaperAuthor(row rowScanner) (PaperAuthor, error) {
+	var author PaperAuthor
+	err := row.Scan(&author.AuthorID, &author.Order, &author.Forename, &author.Surname, &author.Email, &author.ORCID, &author.Corresponding, &author.Affiliations)
+	return author, err
+}
+
+// AffiliationList decodes the affiliations column
+func (author PaperAuthor) AffiliationList() []parsing.Affiliation {
+	var affiliations []parsing.Affiliation
+	if author.Affiliations != nil {
+		json.Unmarshal([]byte(*author.Affiliations), &affiliations)
+	}
+	if affiliations == nil {
+		return []parsing.Affiliation{}
+	}
+	return affiliations
+}
+
+// ReplacePaperAuthors swaps a paper's authors for a new extraction in one transaction.
+// Authors are matched to existing ones of the paper's screen by ORCID, then email, and created otherwise.
+func (store *Store) ReplacePaperAuthors(paperID int64, screenID int64, authors []parsing.Author) error {
+	return store.inTx(func(tx *sql.Tx) error {
+		return replacePaperAuthors(tx, paperID, screenID, authors)
+	})
+}
+
+func replacePaperAuthors(tx *sql.Tx, paperID int64, screenID int64, authors []parsing.Author) error {
+	if _, err := tx.Exec("DELETE FROM paper_authors WHERE paper_id = ?", paperID); err != nil {
+		return err
+	}
+	now := carbon.Now().DateTimeString()
+	for order, author := range authors {
+		authorID, err := findOrCreateAuthor(tx, screenID, author, now)
+		if err != nil {
+			return err
+		}
+		var affiliations *string
+		if len(author.Affiliations) > 0 {
+			encoded, err := json.Marshal(author.Affiliations)
+			if err != nil {
+				return err
+			}
+			value := string(encoded)
+			affiliations = &value
+		}
+		_, err = tx.Exec("INSERT INTO paper_authors (paper_id, author_id, `order`, corresponding, affiliations, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			paperID, authorID, order, author.Corresponding, affiliations, now, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findOrCreateAuthor does not match on names alone, which are too ambiguous to merge authors on. A matched author's
+// name is replaced by a fuller one, so an author first seen as "J." is named "John" once a paper spells it out.
+func findOrCreateAuthor(tx *sql.Tx, screenID int64, author parsing.Author, now string) (int64, error) {
+	for _, match := range []struct{ column, value string }{{"orcid", author.ORCID}, {"email", author.Email}} {
+		if match.value == "" {
+			continue
+		}
+		var id int64
+		var forename, surname *string
+		err := tx.QueryRow("SELECT id, forename, surname FROM authors WHERE screen_id = ? AND "+match.column+" = ? ORDER BY id LIMIT 1", screenID, match.value).Scan(&id, &forename, &surname)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if fullerName(forename, author.Forename) || fullerName(surname, author.Surname) {
+			_, err := tx.Exec("UPDATE authors SET forename = ?, surname = ?, updated_at = ? WHERE id = ?",
+				betterName(forename, author.Forename), betterName(surname, author.Surname), now, id)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return id, nil
+	}
+
+	result, err := tx.Exec("INSERT INTO authors (screen_id, forename, surname, email, orcid, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		screenID, nullString(author.Forename), nullString(author.Surname), nullString(author.Email), nullString(author.ORCID), now, now)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// fullerName reports whether name says more than the stored one, which it does when it is longer
+func fullerName(stored *string, name string) bool {
+	return stored == nil || len([]rune(name)) > len([]rune(*stored))
+}
+
+// betterName keeps the stored name unless name is fuller
+func betterName(stored *string, name string) *string {
+	if name != "" && fullerName(stored, name) {
+		return &name
+	}
+	return stored
+}
+
+// FindAuthorsByPaper returns the authors of a paper in order
+func (store *Store) FindAuthorsByPaper(paperID int64) ([]PaperAuthor, error) {
+	rows, err := store.db.Query("SELECT "+paperAuthorColumns+" FROM paper_authors JOIN authors ON authors.id = paper_authors.author_id WHERE paper_authors.paper_id = ? ORDER BY paper_authors.`order`", paperID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	authors := []PaperAuthor{}
+	for rows.Next() {
+		author, err := scanPaperAuthor(rows)
+		if err != nil {
+			return nil, err
+		}
+		authors = append(authors, author)
+	}
+	return authors, rows.Err()
+}
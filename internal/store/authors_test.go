@@ -0,0 +1,62 @@
+package store
+
+import (
+	"database/sql/driver"
+	"simple-go-app/internal/parsing"
+	"strings"
+	"testing"
+)
+
+// test authors are matched within the paper's screen, new ones created there and paper_authors written in order
+func TestStore_ReplacePaperAuthors(t *testing.T) {
+	s, fake := newFakeStore(t)
+	fake.answer("orcid = ?", []string{"id", "forename", "surname"}, []driver.Value{int64(40), "A", "Silva"})
+
+	err := s.ReplacePaperAuthors(5, 7, []parsing.Author{
+		{Forename: "Ana M", Surname: "Silva", ORCID: "0000-0002-1825-0097", Corresponding: true},
+		{Forename: "João", Surname: "Costa", Email: "joao@example.org"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lookups := fake.matching("SELECT id, forename, surname FROM authors")
+	if len(lookups) != 2 {
+		t.Fatalf("Expected an ORCID and an email lookup, got %+v", lookups)
+	}
+	for _, lookup := range lookups {
+		if !strings.Contains(lookup.query, "screen_id = ?") || lookup.args[0] != int64(7) {
+			t.Errorf("Expected the lookup to be scoped to the screen, got %+v", lookup)
+		}
+	}
+
+	updates := fake.matching("UPDATE authors")
+	if len(updates) != 1 || updates[0].args[0] != "Ana M" || updates[0].args[1] != "Silva" || updates[0].args[3] != int64(40) {
+		t.Errorf("Expected the matched author to get the fuller forename, got %+v", updates)
+	}
+	inserts := fake.matching("INSERT INTO authors")
+	if len(inserts) != 1 || inserts[0].args[0] != int64(7) || inserts[0].args[3] != "joao@example.org" {
+		t.Errorf("Expected the unmatched author to be created in the screen, got %+v", inserts)
+	}
+
+	links := fake.matching("INSERT INTO paper_authors")
+	if len(links) != 2 || links[0].args[1] != int64(40) || links[0].args[3] != true || links[1].args[2] != int64(1) {
+		t.Errorf("Unexpected paper_authors rows %+v", links)
+	}
+	if !fake.committed {
+		t.Errorf("Expected the transaction to be committed")
+	}
+}
+
+// test a matched author keeps a name that is already the fuller one
+func TestStore_ReplacePaperAuthors_KeepsName(t *testing.T) {
+	s, fake := newFakeStore(t)
+	fake.answer("email = ?", []string{"id", "forename", "surname"}, []driver.Value{int64(40), "Ana Maria", "Silva"})
+
+	if err := s.ReplacePaperAuthors(5, 7, []parsing.Author{{Forename: "A", Surname: "Silva", Email: "ana@example.org"}}); err != nil {
+		t.Fatal(err)
+	}
+	if updates := fake.matching("UPDATE authors"); len(updates) != 0 {
+		t.Errorf("Expected the name to be kept, got %+v", updates)
+	}
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"database/sql"
+	"github.com/uniplaces/carbon"
+)
+
+// Citation is a row of the citations table, an inline citation marker in a section's text
+type Citation struct {
+	ID          int64   `json:"id"`
+	SectionID   int64   `json:"section_id"`
+	ReferenceID *int64  `json:"reference_id,omitempty"`
+	Offset      int64   `json:"offset"`
+	Marker      *string `json:"marker,omitempty"`
+	Target      *string `json:"target,omitempty"`
+}
+
+const citationColumns = "citations.id, citations.section_id, citations.reference_id, citations.`offset`, citations.marker, citations.target"
+
+func scanCitation(row rowScanner) (Citation, error) {
+	var citation Citation
+	err := row.Scan(&citation.ID, &citation.SectionID, &citation.ReferenceID, &citation.Offset, &citation.Marker, &citation.Target)
+	return citation, err
+}
+
+// ReplaceCitations swaps the citations of a paper's sections for those of a new extraction, linking them to the
+// paper's references by TEI id. It runs after the sections, which must have their IDs, and the references are written.
+func (store *Store) ReplaceCitations(paperID int64, sections []Section) error {
+	return store.inTx(func(tx *sql.Tx) error {
+		return replaceCitations(tx, paperID, sections)
+	})
+}
+
+func replaceCitations(tx *sql.Tx, paperID int64, sections []Section) error {
+	rows, err := tx.Query("SELECT id, tei_id FROM `references` WHERE paper_id = ? AND tei_id IS NOT NULL", paperID)
+	if err != nil {
+		return err
+	}
+	references := make(map[string]int64)
+	for rows.Next() {
+		var id int64
+		var teiID string
+		if err := rows.Scan(&id, &teiID); err != nil {
+			rows.Close()
+			return err
+		}
+		references[teiID] = id
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("DELETE citations FROM citations JOIN sections ON sections.id = citations.section_id WHERE sections.paper_id = ?", paperID); err != nil {
+		return err
+	}
+	now := carbon.Now().DateTimeString()
+	for _, section := range sections {
+		if section.ID == 0 {
+			continue
+		}
+		for _, citation := range section.Citations {
+			var referenceID *int64
+			if id, ok := references[citation.Target]; ok {
+				referenceID = &id
+			}
+			_, err := tx.Exec("INSERT INTO citations (section_id, reference_id, `offset`, marker, target, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+				section.ID, referenceID, citation.Offset, nullString(citation.Marker), nullString(citation.Target), now, now)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// FindCitationsByPaper returns the citations in a paper's sections in reading order
+func (store *Store) FindCitationsByPaper(paperID int64) ([]Citation, error) {
+	rows, err := store.db.Query("SELECT "+citationColumns+" FROM citations JOIN sections ON sections.id = citations.section_id WHERE sections.paper_id = ? ORDER BY sections.`order`, citations.`offset`", paperID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	citations := []Citation{}
+	for rows.Next() {
+		citation, err := scanCitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		citations = append(citations, citation)
+	}
+	return citations, rows.Err()
+}
@@ -0,0 +1,38 @@
+package store
+
+import (
+	"database/sql/driver"
+	"simple-go-app/internal/parsing"
+	"testing"
+)
+
+// test citations are linked to the paper's references by TEI id and sections without an id are skipped
+func TestStore_ReplaceCitations(t *testing.T) {
+	s, fake := newFakeStore(t)
+	fake.answer("FROM `references`", []string{"id", "tei_id"}, []driver.Value{int64(30), "b0"})
+
+	err := s.ReplaceCitations(5, []Section{
+		{ID: 10, Citations: []parsing.Citation{{Offset: 22, Marker: "[1]", Target: "b0"}, {Offset: 40, Marker: "[2]"}}},
+		{Citations: []parsing.Citation{{Offset: 1, Marker: "[3]", Target: "b0"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if deletes := fake.matching("DELETE citations"); len(deletes) != 1 || deletes[0].args[0] != int64(5) {
+		t.Errorf("Expected the paper's citations to be deleted, got %+v", deletes)
+	}
+	inserts := fake.matching("INSERT INTO citations")
+	if len(inserts) != 2 {
+		t.Fatalf("Expected 2 inserts, got %d", len(inserts))
+	}
+	if linked := inserts[0].args; linked[0] != int64(10) || linked[1] != int64(30) || linked[2] != int64(22) || linked[4] != "b0" {
+		t.Errorf("Unexpected linked citation %v", linked)
+	}
+	if unlinked := inserts[1].args; unlinked[1] != nil || unlinked[3] != "[2]" || unlinked[4] != nil {
+		t.Errorf("Unexpected unlinked citation %v", unlinked)
+	}
+	if !fake.committed {
+		t.Errorf("Expected the transaction to be committed")
+	}
+}
@@ -0,0 +1,52 @@
+package store
+
+import (
+	"encoding/json"
+	"simple-go-app/internal/parsing"
+)
+
+// encodeBoxes stores PDF coordinates as a JSON array, NULL when Grobid gave none
+func encodeBoxes(boxes []parsing.Box) *string {
+	if len(boxes) == 0 {
+		return nil
+	}
+	encoded, _ := json.Marshal(boxes)
+	value := string(encoded)
+	return &value
+}
+
+func decodeBoxes(coords *string) []parsing.Box {
+	var boxes []parsing.Box
+	if coords != nil {
+		json.Unmarshal([]byte(*coords), &boxes)
+	}
+	if boxes == nil {
+		return []parsing.Box{}
+	}
+	return boxes
+}
+
+// SetBoxes sets the coordinates of a section and the page it starts on
+func (section *Section) SetBoxes(boxes []parsing.Box) {
+	section.Coords = encodeBoxes(boxes)
+	section.Page = nil
+	if len(boxes) > 0 {
+		page := int64(boxes[0].Page)
+		section.Page = &page
+	}
+}
+
+// BoxList decodes the coords column
+func (section Section) BoxList() []parsing.Box {
+	return decodeBoxes(section.Coords)
+}
+
+// BoxList decodes the coords column
+func (figure Figure) BoxList() []parsing.Box {
+	return decodeBoxes(figure.Coords)
+}
+
+// BoxList decodes the coords column
+func (reference Reference) BoxList() []parsing.Box {
+	return decodeBoxes(reference.Coords)
+}
@@ -0,0 +1,159 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeDB is a database/sql driver that records the statements run against it and answers queries with canned
+// rows, so the SQL a store method writes can be tested without MySQL
+type fakeDB struct {
+	mu         sync.Mutex
+	statements []fakeStatement
+	results    []fakeResult
+	lastID     int64
+	committed  bool
+	rolledBack bool
+}
+
+// fakeStatement is a query or exec run against a fakeDB
+type fakeStatement struct {
+	query string
+	args  []driver.Value
+}
+
+// fakeResult answers the first query containing match with rows, or fails the statement with err
+type fakeResult struct {
+	match   string
+	columns []string
+	rows    [][]driver.Value
+	err     error
+}
+
+func newFakeStore(t *testing.T) (*Store, *fakeDB) {
+	fake := &fakeDB{}
+	db := sql.OpenDB(fake)
+	t.Cleanup(func() { db.Close() })
+	return New(db), fake
+}
+
+// answer makes queries containing match return rows
+func (fake *fakeDB) answer(match string, columns []string, rows ...[]driver.Value) {
+	fake.results = append(fake.results, fakeResult{match: match, columns: columns, rows: rows})
+}
+
+// fail makes statements containing match return err
+func (fake *fakeDB) fail(match string, err error) {
+	fake.results = append(fake.results, fakeResult{match: match, err: err})
+}
+
+// run records a statement and returns the result registered for it, if any
+func (fake *fakeDB) run(query string, args []driver.Value) (fakeResult, error) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	fake.statements = append(fake.statements, fakeStatement{query: query, args: args})
+	for _, result := range fake.results {
+		if strings.Contains(query, result.match) {
+			return result, result.err
+		}
+	}
+	return fakeResult{}, nil
+}
+
+// matching returns the statements containing match in the order they ran
+func (fake *fakeDB) matching(match string) []fakeStatement {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	var statements []fakeStatement
+	for _, statement := range fake.statements {
+		if strings.Contains(statement.query, match) {
+			statements = append(statements, statement)
+		}
+	}
+	return statements
+}
+
+func (fake *fakeDB) Connect(context.Context) (driver.Conn, error) { return fakeConn{fake}, nil }
+func (fake *fakeDB) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ fake *fakeDB }
+
+func (conn fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{conn.fake, query}, nil
+}
+func (conn fakeConn) Close() error              { return nil }
+func (conn fakeConn) Begin() (driver.Tx, error) { return fakeTx{conn.fake}, nil }
+
+type fakeTx struct{ fake *fakeDB }
+
+func (tx fakeTx) Commit() error {
+	tx.fake.mu.Lock()
+	defer tx.fake.mu.Unlock()
+	tx.fake.committed = true
+	return nil
+}
+
+func (tx fakeTx) Rollback() error {
+	tx.fake.mu.Lock()
+	defer tx.fake.mu.Unlock()
+	tx.fake.rolledBack = true
+	return nil
+}
+
+type fakeStmt struct {
+	fake  *fakeDB
+	query string
+}
+
+func (stmt fakeStmt) Close() error  { return nil }
+func (stmt fakeStmt) NumInput() int { return -1 }
+
+func (stmt fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if _, err := stmt.fake.run(stmt.query, args); err != nil {
+		return nil, err
+	}
+	stmt.fake.mu.Lock()
+	defer stmt.fake.mu.Unlock()
+	stmt.fake.lastID++
+	return fakeExecResult(stmt.fake.lastID), nil
+}
+
+// fakeExecResult gives each exec the next insert id
+type fakeExecResult int64
+
+func (result fakeExecResult) LastInsertId() (int64, error) { return int64(result), nil }
+func (result fakeExecResult) RowsAffected() (int64, error) { return 1, nil }
+
+func (stmt fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	result, err := stmt.fake.run(stmt.query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &fakeRows{columns: result.columns, rows: result.rows}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (rows *fakeRows) Columns() []string { return rows.columns }
+func (rows *fakeRows) Close() error      { return nil }
+
+func (rows *fakeRows) Next(dest []driver.Value) error {
+	if len(rows.rows) == 0 {
+		return io.EOF
+	}
+	if len(dest) != len(rows.rows[0]) {
+		return errors.New("fakedb: row does not match its columns")
+	}
+	copy(dest, rows.rows[0])
+	rows.rows = rows.rows[1:]
+	return nil
+}
@@ -0,0 +1,100 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"simple-go-app/internal/parsing"
+
+	"github.com/uniplaces/carbon"
+)
+
+// Figure is a row of the figures table, a figure or a table of a paper
+type Figure struct {
+	ID        int64   `json:"id"`
+	PaperID   int64   `json:"paper_id"`
+	Order     int64   `json:"order"`
+	TEIID     *string `json:"tei_id,omitempty"`
+	Type      string  `json:"type"`
+	Label     *string `json:"label,omitempty"`
+	Caption   *string `json:"caption,omitempty"`
+	Cells     *string `json:"-"` // a JSON array of rows, see CellRows
+	Coords    *string `json:"-"` // a JSON array of boxes, see BoxList
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+const figureColumns = "id, paper_id, `order`, tei_id, type, label, caption, cells, coords, created_at, updated_at"
+
+func scanFigure(row rowScanner) (Figure, error) {
+	var figure Figure
+	err := row.Scan(&figure.ID, &figure.PaperID, &figure.Order, &figure.TEIID, &figure.Type, &figure.Label, &figure.Caption, &figure.Cells, &figure.Coords, &figure.CreatedAt, &figure.UpdatedAt)
+	return figure, err
+}
+
+// CellRows decodes the cells column, empty for figures
+func (figure Figure) CellRows() [][]string {
+	var rows [][]string
+	if figure.Cells != nil {
+		json.Unmarshal([]byte(*figure.Cells), &rows)
+	}
+	if rows == nil {
+		return [][]string{}
+	}
+	return rows
+}
+
+// ReplaceFigures swaps a paper's figures and tables for a new extraction in one transaction
+func (store *Store) ReplaceFigures(paperID int64, figures []parsing.Figure) error {
+	return store.inTx(func(tx *sql.Tx) error {
+		return replaceFigures(tx, paperID, figures)
+	})
+}
+
+func replaceFigures(tx *sql.Tx, paperID int64, figures []parsing.Figure) error {
+	if _, err := tx.Exec("DELETE FROM figures WHERE paper_id = ?", paperID); err != nil {
+		return err
+	}
+	now := carbon.Now().DateTimeString()
+	for order, figure := range figures {
+		var cells *string
+		if len(figure.Rows) > 0 {
+			encoded, err := json.Marshal(figure.Rows)
+			if err != nil {
+				return err
+			}
+			value := string(encoded)
+			cells = &value
+		}
+		_, err := tx.Exec("INSERT INTO figures (paper_id, `order`, tei_id, type, label, caption, cells, coords, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			paperID, order, nullString(figure.TEIID), figure.Type, nullString(figure.Label), nullString(figure.Caption), cells, encodeBoxes(figure.Coords), now, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindFiguresByPaper returns the figures and tables of a paper in order
+func (store *Store) FindFiguresByPaper(paperID int64) ([]Figure, error) {
+	rows, err := store.db.Query("SELECT "+figureColumns+" FROM figures WHERE paper_id = ? ORDER BY `order`", paperID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	figures := []Figure{}
+	for rows.Next() {
+		figure, err := scanFigure(rows)
+		if err != nil {
+			return nil, err
+		}
+		figures = append(figures, figure)
+	}
+	return figures, rows.Err()
+}
+
+// FindFigure returns a figure of a paper, sql.ErrNoRows when the paper has no such figure
+func (store *Store) FindFigure(paperID int64, figureID int64) (Figure, error) {
+	return scanFigure(store.db.QueryRow("SELECT "+figureColumns+" FROM figures WHERE paper_id = ? AND id = ?", paperID, figureID))
+}
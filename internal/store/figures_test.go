@@ -0,0 +1,57 @@
+package store
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"simple-go-app/internal/parsing"
+	"testing"
+)
+
+// test a paper's figures are deleted and inserted again in order, tables with their cells
+func TestStore_ReplaceFigures(t *testing.T) {
+	s, fake := newFakeStore(t)
+
+	err := s.ReplaceFigures(5, []parsing.Figure{
+		{TEIID: "fig_0", Type: parsing.FigureTypeFigure, Label: "Fig. 1", Caption: "Survival"},
+		{TEIID: "tab_0", Type: parsing.FigureTypeTable, Label: "Table 1", Rows: [][]string{{"Group", "n"}, {"Control", "30"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if deletes := fake.matching("DELETE FROM figures"); len(deletes) != 1 || deletes[0].args[0] != int64(5) {
+		t.Errorf("Expected the paper's figures to be deleted, got %+v", deletes)
+	}
+	inserts := fake.matching("INSERT INTO figures")
+	if len(inserts) != 2 {
+		t.Fatalf("Expected 2 inserts, got %d", len(inserts))
+	}
+	if figure := inserts[0].args; figure[1] != int64(0) || figure[3] != parsing.FigureTypeFigure || figure[6] != nil {
+		t.Errorf("Unexpected figure %v", figure)
+	}
+	if table := inserts[1].args; table[1] != int64(1) || table[5] != nil || table[6] != `[["Group","n"],["Control","30"]]` {
+		t.Errorf("Unexpected table %v", table)
+	}
+	if !fake.committed {
+		t.Errorf("Expected the transaction to be committed")
+	}
+}
+
+// test a table is read back with its cells decoded
+func TestStore_FindFigure(t *testing.T) {
+	s, fake := newFakeStore(t)
+	fake.answer("FROM figures", []string{"id", "paper_id", "order", "tei_id", "type", "label", "caption", "cells", "coords", "created_at", "updated_at"},
+		[]driver.Value{int64(2), int64(5), int64(1), "tab_0", parsing.FigureTypeTable, "Table 1", nil, `[["Group","n"]]`, nil, "2024-05-01 10:00:00", "2024-05-01 10:00:00"},
+	)
+
+	figure, err := s.FindFigure(5, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if figure.Type != parsing.FigureTypeTable || figure.Caption != nil || !reflect.DeepEqual(figure.CellRows(), [][]string{{"Group", "n"}}) {
+		t.Errorf("Unexpected figure %+v", figure)
+	}
+	if args := fake.statements[0].args; args[0] != int64(5) || args[1] != int64(2) {
+		t.Errorf("Expected the figure to be looked up within its paper, got %v", args)
+	}
+}
@@ -0,0 +1,60 @@
+package store
+
+import "strings"
+
+// LogFilter selects log entries, empty fields are ignored
+type LogFilter struct {
+	ScreenID int64
+	UserID   int64
+	Levels   []string
+	Stage    string
+}
+
+// FindLogsAfter returns up to limit log entries with an id greater than afterID, oldest first
+func (store *Store) FindLogsAfter(afterID int64, filter LogFilter, limit int) ([]Log, error) {
+	where := []string{"id > ?"}
+	args := []any{afterID}
+	if filter.ScreenID != 0 {
+		where = append(where, "screen_id = ?")
+		args = append(args, filter.ScreenID)
+	}
+	if filter.UserID != 0 {
+		where = append(where, "user_id = ?")
+		args = append(args, filter.UserID)
+	}
+	if len(filter.Levels) > 0 {
+		where = append(where, "level IN (?"+strings.Repeat(", ?", len(filter.Levels)-1)+")")
+		for _, level := range filter.Levels {
+			args = append(args, level)
+		}
+	}
+	if filter.Stage != "" {
+		where = append(where, "stage = ?")
+		args = append(args, filter.Stage)
+	}
+	args = append(args, limit)
+
+	rows, err := store.db.Query("SELECT id, level, user_message, COALESCE(full_log, ''), COALESCE(stage, ''), user_id, screen_id, created_at FROM logs WHERE "+strings.Join(where, " AND ")+" ORDER BY id LIMIT ?", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []Log
+	for rows.Next() {
+		var entry Log
+		err := rows.Scan(&entry.ID, &entry.Level, &entry.UserMessage, &entry.FullLog, &entry.Stage, &entry.UserID, &entry.ScreenID, &entry.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, entry)
+	}
+	return logs, rows.Err()
+}
+
+// LastLogID returns the id of the newest log entry, so a tail can start from now
+func (store *Store) LastLogID() (int64, error) {
+	var id int64
+	err := store.db.QueryRow("SELECT COALESCE(MAX(id), 0) FROM logs").Scan(&id)
+	return id, err
+}
@@ -0,0 +1,69 @@
+package store
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var logTestColumns = []string{"id", "level", "user_message", "full_log", "stage", "user_id", "screen_id", "created_at"}
+
+// test the filter narrows the query and the entries are read in order
+func TestStore_FindLogsAfter(t *testing.T) {
+	s, fake := newFakeStore(t)
+	fake.answer("FROM logs", logTestColumns,
+		[]driver.Value{int64(11), "error", "Grobid failed", "", "pdf_processing", int64(3), int64(7), "2024-05-01 10:00:00"},
+		[]driver.Value{int64(12), "warning", "Slow", "details", "", int64(3), int64(7), "2024-05-01 10:00:01"},
+	)
+
+	logs, err := s.FindLogsAfter(10, LogFilter{ScreenID: 7, Levels: []string{"error", "warning"}, Stage: "pdf_processing"}, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Log{
+		{ID: 11, Level: "error", UserMessage: "Grobid failed", Stage: "pdf_processing", UserID: 3, ScreenID: 7, CreatedAt: "2024-05-01 10:00:00"},
+		{ID: 12, Level: "warning", UserMessage: "Slow", FullLog: "details", UserID: 3, ScreenID: 7, CreatedAt: "2024-05-01 10:00:01"},
+	}
+	if !reflect.DeepEqual(logs, want) {
+		t.Errorf("Unexpected logs: %+v", logs)
+	}
+
+	query := fake.statements[0]
+	if !strings.Contains(query.query, "WHERE id > ? AND screen_id = ? AND level IN (?, ?) AND stage = ? ORDER BY id LIMIT ?") {
+		t.Errorf("Unexpected query: %s", query.query)
+	}
+	if want := []driver.Value{int64(10), int64(7), "error", "warning", "pdf_processing", int64(50)}; !reflect.DeepEqual(query.args, want) {
+		t.Errorf("Unexpected arguments: %v", query.args)
+	}
+}
+
+// test a tail with no filter only pages by id
+func TestStore_FindLogsAfter_NoFilter(t *testing.T) {
+	s, fake := newFakeStore(t)
+
+	logs, err := s.FindLogsAfter(0, LogFilter{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 0 {
+		t.Errorf("Expected no logs, got %+v", logs)
+	}
+	if query := fake.statements[0].query; !strings.Contains(query, "WHERE id > ? ORDER BY id LIMIT ?") {
+		t.Errorf("Unexpected query: %s", query)
+	}
+}
+
+// test the newest id is returned
+func TestStore_LastLogID(t *testing.T) {
+	s, fake := newFakeStore(t)
+	fake.answer("MAX(id)", []string{"id"}, []driver.Value{int64(42)})
+
+	id, err := s.LastLogID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 42 {
+		t.Errorf("Expected 42, got %d", id)
+	}
+}
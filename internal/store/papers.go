@@ -0,0 +1,118 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"simple-go-app/internal/parsing"
+	"strings"
+)
+
+// PaperFilter narrows down the papers of a screen, empty fields are ignored
+type PaperFilter struct {
+	DOI   string
+	Title string
+	Year  string
+}
+
+func (store *Store) FindPaperByID(id int64) (Paper, error) {
+	return scanPaper(store.db.QueryRow("SELECT "+paperColumns+" FROM papers WHERE id = ?", id))
+}
+
+func (store *Store) FindPapersByScreen(screenID int64) ([]Paper, error) {
+	papers, _, err := store.ListPapers(screenID, PaperFilter{}, -1, 0)
+	return papers, err
+}
+
+// ListPapers returns a page of a screen's papers ordered by id and the number of papers matching the filter.
+// A negative limit returns every paper.
+func (store *Store) ListPapers(screenID int64, filter PaperFilter, limit, offset int) ([]Paper, int64, error) {
+	where := []string{"screen_id = ?"}
+	args := []any{screenID}
+	if filter.DOI != "" {
+		where = append(where, "doi = ?")
+		args = append(args, filter.DOI)
+	}
+	if filter.Title != "" {
+		where = append(where, "title LIKE ?")
+		args = append(args, "%"+escapeLike(filter.Title)+"%")
+	}
+	if filter.Year != "" {
+		where = append(where, "year = ?")
+		args = append(args, filter.Year)
+	}
+	conditions := strings.Join(where, " AND ")
+
+	var total int64
+	if err := store.db.QueryRow("SELECT COUNT(*) FROM papers WHERE "+conditions, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := "SELECT " + paperColumns + " FROM papers WHERE " + conditions + " ORDER BY id"
+	if limit >= 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, limit, offset)
+	}
+	rows, err := store.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	papers := []Paper{}
+	for rows.Next() {
+		paper, err := scanPaper(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		papers = append(papers, paper)
+	}
+	return papers, total, rows.Err()
+}
+
+// KeywordList decodes the keywords column
+func (paper Paper) KeywordList() []string {
+	var keywords []string
+	if paper.Keywords != nil {
+		json.Unmarshal([]byte(*paper.Keywords), &keywords)
+	}
+	if keywords == nil {
+		return []string{}
+	}
+	return keywords
+}
+
+// encodeKeywords stores keywords as a JSON array, NULL when there are none
+func encodeKeywords(keywords []string) (*string, error) {
+	if len(keywords) == 0 {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(keywords)
+	if err != nil {
+		return nil, err
+	}
+	value := string(encoded)
+	return &value, nil
+}
+
+// encodePublishedDate fills in the unknown month and day with 1 to fit the date column, the precision says which
+// parts are real. Both are NULL without a date.
+func encodePublishedDate(date *parsing.PublishedDate) (*string, *string) {
+	if date == nil {
+		return nil, nil
+	}
+	month, day := date.Month, date.Day
+	if month == 0 {
+		month = 1
+	}
+	if day == 0 {
+		day = 1
+	}
+	value := fmt.Sprintf("%04d-%02d-%02d", date.Year, month, day)
+	precision := string(date.Precision)
+	return &value, &precision
+}
+
+// escapeLike stops user input being read as LIKE wildcards
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
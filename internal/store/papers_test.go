@@ -0,0 +1,141 @@
+package store
+
+import (
+	"database/sql/driver"
+	"simple-go-app/internal/parsing"
+	"strings"
+	"testing"
+)
+
+// insertedValue returns the value an INSERT statement writes to column
+func insertedValue(t *testing.T, statement fakeStatement, column string) driver.Value {
+	t.Helper()
+	columns := statement.query[strings.Index(statement.query, "(")+1 : strings.Index(statement.query, ")")]
+	for i, name := range strings.Split(columns, ", ") {
+		if name == column {
+			return statement.args[i]
+		}
+	}
+	t.Fatalf("%s is not inserted by %s", column, statement.query)
+	return nil
+}
+
+// test the venue and publication date of a paper are written, issues that are not numbers included
+func TestStore_CreatePaper(t *testing.T) {
+	s, fake := newFakeStore(t)
+	columns, row := paperRow(1)
+	fake.answer("FROM papers", columns, row)
+
+	_, err := s.CreatePaper(&parsing.PDFDTO{
+		Title:         "A paper",
+		DOI:           "10.1000/a",
+		Venue:         parsing.Venue{Volume: "251", Issue: "Suppl 1", Pages: "126-135"},
+		Year:          "2020",
+		PublishedDate: &parsing.PublishedDate{Year: 2020, Month: 7, Precision: parsing.DatePrecisionMonth},
+	}, 3, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inserts := fake.matching("INSERT INTO papers")
+	if len(inserts) != 1 {
+		t.Fatalf("Expected 1 insert, got %d", len(inserts))
+	}
+	want := map[string]driver.Value{
+		"volume":                   "251",
+		"issue":                    "Suppl 1",
+		"pages":                    "126-135",
+		"published_date":           "2020-07-01",
+		"published_date_precision": "month",
+		"publisher":                nil,
+	}
+	for column, value := range want {
+		if got := insertedValue(t, inserts[0], column); got != value {
+			t.Errorf("Expected %s to be %v, got %v", column, value, got)
+		}
+	}
+}
+
+// test a paper without a date stores none
+func TestStore_CreatePaper_NoDate(t *testing.T) {
+	s, fake := newFakeStore(t)
+	columns, row := paperRow(1)
+	fake.answer("FROM papers", columns, row)
+
+	if _, err := s.CreatePaper(&parsing.PDFDTO{Title: "A paper"}, 3, 7); err != nil {
+		t.Fatal(err)
+	}
+	inserts := fake.matching("INSERT INTO papers")
+	if date := insertedValue(t, inserts[0], "published_date"); date != nil {
+		t.Errorf("Expected no date, got %v", date)
+	}
+	if issue := insertedValue(t, inserts[0], "issue"); issue != nil {
+		t.Errorf("Expected no issue, got %v", issue)
+	}
+}
+
+// test a paper without a DOI is read back by the id it was inserted with, not by its empty DOI
+func TestStore_CreatePaper_ReadsBackByID(t *testing.T) {
+	s, fake := newFakeStore(t)
+	columns, row := paperRow(1)
+	fake.answer("FROM papers", columns, row)
+
+	paper, err := s.CreatePaper(&parsing.PDFDTO{Title: "A paper"}, 3, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lookups := fake.matching("FROM papers")
+	if len(lookups) != 1 || !strings.Contains(lookups[0].query, "WHERE id = ?") || lookups[0].args[0] != int64(1) {
+		t.Errorf("Expected the paper to be read back by its id, got %+v", lookups)
+	}
+	if paper.ID != 1 {
+		t.Errorf("Unexpected paper %+v", paper)
+	}
+}
+
+// test the date is read back with its precision
+func TestStore_FindPaperByID(t *testing.T) {
+	s, fake := newFakeStore(t)
+	columns, row := paperRow(1)
+	row[indexOf(columns, "issue")] = "3-4"
+	row[indexOf(columns, "published_date")] = []byte("2020-01-01")
+	row[indexOf(columns, "published_date_precision")] = "year"
+	fake.answer("FROM papers", columns, row)
+
+	paper, err := s.FindPaperByID(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paper.Issue == nil || *paper.Issue != "3-4" {
+		t.Errorf("Unexpected issue %v", paper.Issue)
+	}
+	if paper.PublishedDate == nil || *paper.PublishedDate != "2020-01-01" || *paper.PublishedDatePrecision != "year" {
+		t.Errorf("Unexpected date %v with precision %v", paper.PublishedDate, paper.PublishedDatePrecision)
+	}
+}
+
+// paperRow returns the columns of a papers row and a row for the paper id with the required columns set
+func paperRow(id int64) ([]string, []driver.Value) {
+	columns := strings.Split(paperColumns, ", ")
+	row := make([]driver.Value, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id":
+			row[i] = id
+		case "user_id", "screen_id":
+			row[i] = int64(1)
+		case "slug", "title", "abstract", "created_at", "updated_at":
+			row[i] = column
+		}
+	}
+	return columns, row
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
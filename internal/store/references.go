@@ -0,0 +1,101 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"simple-go-app/internal/parsing"
+
+	"github.com/uniplaces/carbon"
+)
+
+// Reference is a row of the references table, an entry of a paper's bibliography
+type Reference struct {
+	ID        int64   `json:"id"`
+	PaperID   int64   `json:"paper_id"`
+	Order     int64   `json:"order"`
+	TEIID     *string `json:"tei_id,omitempty"`
+	Title     *string `json:"title,omitempty"`
+	Authors   *string `json:"-"` // a JSON array, see AuthorList
+	Year      *string `json:"year,omitempty"`
+	Venue     *string `json:"venue,omitempty"`
+	DOI       *string `json:"doi,omitempty"`
+	Raw       *string `json:"raw,omitempty"`
+	Coords    *string `json:"-"` // a JSON array of boxes, see BoxList
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+const referenceColumns = "id, paper_id, `order`, tei_id, title, authors, year, venue, doi, raw, coords, created_at, updated_at"
+
+func scanReference(row rowScanner) (Reference, error) {
+	var reference Reference
+	err := row.Scan(&reference.ID, &reference.PaperID, &reference.Order, &reference.TEIID, &reference.Title, &reference.Authors, &reference.Year, &reference.Venue, &reference.DOI, &reference.Raw, &reference.Coords, &reference.CreatedAt, &reference.UpdatedAt)
+	return reference, err
+}
+
+// AuthorList decodes the authors column
+func (reference Reference) AuthorList() []string {
+	var authors []string
+	if reference.Authors != nil {
+		json.Unmarshal([]byte(*reference.Authors), &authors)
+	}
+	if authors == nil {
+		return []string{}
+	}
+	return authors
+}
+
+// ReplaceReferences swaps a paper's bibliography for a new extraction in one transaction
+func (store *Store) ReplaceReferences(paperID int64, references []parsing.Reference) error {
+	return store.inTx(func(tx *sql.Tx) error {
+		return replaceReferences(tx, paperID, references)
+	})
+}
+
+func replaceReferences(tx *sql.Tx, paperID int64, references []parsing.Reference) error {
+	if _, err := tx.Exec("DELETE FROM `references` WHERE paper_id = ?", paperID); err != nil {
+		return err
+	}
+	now := carbon.Now().DateTimeString()
+	for order, reference := range references {
+		// the authors column reuses the JSON array encoding of keywords
+		authors, err := encodeKeywords(reference.Authors)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec("INSERT INTO `references` (paper_id, `order`, tei_id, title, authors, year, venue, doi, raw, coords, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			paperID, order, nullString(reference.TEIID), nullString(reference.Title), authors, nullString(reference.Year), nullString(reference.Venue), nullString(reference.DOI), nullString(reference.Raw), encodeBoxes(reference.Coords), now, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindReferencesByPaper returns the bibliography of a paper in order
+func (store *Store) FindReferencesByPaper(paperID int64) ([]Reference, error) {
+	rows, err := store.db.Query("SELECT "+referenceColumns+" FROM `references` WHERE paper_id = ? ORDER BY `order`", paperID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	references := []Reference{}
+	for rows.Next() {
+		reference, err := scanReference(rows)
+		if err != nil {
+			return nil, err
+		}
+		references = append(references, reference)
+	}
+	return references, rows.Err()
+}
+
+// nullString stores an empty string as NULL
+func nullString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"database/sql/driver"
+	"errors"
+	"simple-go-app/internal/parsing"
+	"testing"
+)
+
+// test a paper's references are deleted and inserted again in order in one transaction
+func TestStore_ReplaceReferences(t *testing.T) {
+	s, fake := newFakeStore(t)
+
+	err := s.ReplaceReferences(5, []parsing.Reference{
+		{TEIID: "b0", Title: "A paper", Authors: []string{"A. Author"}, Year: "2020", Venue: "Nature", DOI: "10.1000/a"},
+		{Raw: "An unparsed citation", Coords: []parsing.Box{{Page: 9, X: 1, Y: 2, Width: 3, Height: 4}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if deletes := fake.matching("DELETE FROM `references`"); len(deletes) != 1 || deletes[0].args[0] != int64(5) {
+		t.Errorf("Expected the paper's references to be deleted, got %+v", deletes)
+	}
+	inserts := fake.matching("INSERT INTO `references`")
+	if len(inserts) != 2 {
+		t.Fatalf("Expected 2 inserts, got %d", len(inserts))
+	}
+	first := inserts[0].args
+	if first[1] != int64(0) || first[2] != "b0" || first[4] != `["A. Author"]` || first[6] != "Nature" || first[9] != nil {
+		t.Errorf("Unexpected first reference %v", first)
+	}
+	second := inserts[1].args
+	if second[1] != int64(1) || second[2] != nil || second[4] != nil || second[8] != "An unparsed citation" || second[9] != `[{"page":9,"x":1,"y":2,"width":3,"height":4}]` {
+		t.Errorf("Unexpected second reference %v", second)
+	}
+	if !fake.committed {
+		t.Errorf("Expected the transaction to be committed")
+	}
+}
+
+// test a failed insert rolls the replacement back
+func TestStore_ReplaceReferences_Rollback(t *testing.T) {
+	s, fake := newFakeStore(t)
+	fake.fail("INSERT INTO `references`", errors.New("data too long"))
+
+	if err := s.ReplaceReferences(5, []parsing.Reference{{Title: "A paper"}}); err == nil {
+		t.Fatal("Expected the insert error")
+	}
+	if fake.committed || !fake.rolledBack {
+		t.Errorf("Expected the transaction to be rolled back")
+	}
+}
+
+// test references are read back in order with their authors decoded
+func TestStore_FindReferencesByPaper(t *testing.T) {
+	s, fake := newFakeStore(t)
+	fake.answer("FROM `references`", []string{"id", "paper_id", "order", "tei_id", "title", "authors", "year", "venue", "doi", "raw", "coords", "created_at", "updated_at"},
+		[]driver.Value{int64(1), int64(5), int64(0), "b0", "A paper", `["A. Author","B. Author"]`, "2020", nil, nil, nil, nil, "2024-05-01 10:00:00", "2024-05-01 10:00:00"},
+	)
+
+	references, err := s.FindReferencesByPaper(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(references) != 1 || *references[0].Title != "A paper" || references[0].Venue != nil {
+		t.Fatalf("Unexpected references %+v", references)
+	}
+	if authors := references[0].AuthorList(); len(authors) != 2 || authors[1] != "B. Author" {
+		t.Errorf("Unexpected authors %v", authors)
+	}
+}
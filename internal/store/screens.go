@@ -0,0 +1,43 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"simple-go-app/internal/parsing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/uniplaces/carbon"
+)
+
+// FindScreenGrobidOptions returns the Grobid options a screen's PDFs are processed with, empty when the screen
+// keeps the defaults, sql.ErrNoRows when there is no such screen
+func (store *Store) FindScreenGrobidOptions(screenID int64) (parsing.GrobidOptions, error) {
+	var opts parsing.GrobidOptions
+	var encoded []byte
+	err := store.db.QueryRow("SELECT screen_grobid_options.options FROM screens LEFT JOIN screen_grobid_options ON screen_grobid_options.screen_id = screens.id WHERE screens.id = ?", screenID).Scan(&encoded)
+	if err != nil || encoded == nil {
+		return opts, err
+	}
+	err = json.Unmarshal(encoded, &opts)
+	return opts, err
+}
+
+// errForeignKey is the MySQL error number of a row referencing a missing parent row
+const errForeignKey = 1452
+
+// SaveScreenGrobidOptions replaces the Grobid options of a screen, sql.ErrNoRows when there is no such screen
+func (store *Store) SaveScreenGrobidOptions(screenID int64, opts parsing.GrobidOptions) error {
+	encoded, err := json.Marshal(opts)
+	if err != nil {
+		return err
+	}
+	now := carbon.Now().DateTimeString()
+	_, err = store.db.Exec("INSERT INTO screen_grobid_options (screen_id, options, created_at, updated_at) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE options = VALUES(options), updated_at = VALUES(updated_at)",
+		screenID, encoded, now, now)
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == errForeignKey {
+		return sql.ErrNoRows
+	}
+	return err
+}
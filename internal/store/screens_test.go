@@ -0,0 +1,39 @@
+package store
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"simple-go-app/internal/parsing"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// test options saved for a screen that does not exist fail with sql.ErrNoRows
+func TestStore_SaveScreenGrobidOptions_NoScreen(t *testing.T) {
+	s, fake := newFakeStore(t)
+	fake.fail("INSERT INTO screen_grobid_options", &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row"})
+
+	level := parsing.ConsolidateFull
+	err := s.SaveScreenGrobidOptions(7, parsing.GrobidOptions{ConsolidateCitations: &level})
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+// test a screen without options of its own keeps the defaults, and a screen that does not exist is sql.ErrNoRows
+func TestStore_FindScreenGrobidOptions(t *testing.T) {
+	s, fake := newFakeStore(t)
+	fake.answer("FROM screens", []string{"options"}, []driver.Value{nil})
+
+	opts, err := s.FindScreenGrobidOptions(7)
+	if err != nil || opts != (parsing.GrobidOptions{}) {
+		t.Errorf("Expected the defaults, got %+v and %v", opts, err)
+	}
+
+	s, _ = newFakeStore(t)
+	if _, err := s.FindScreenGrobidOptions(7); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows, got %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package store
+
+import (
+	"database/sql"
+	"simple-go-app/internal/parsing"
+
+	"github.com/uniplaces/carbon"
+)
+
+// SectionChanges counts what ReplaceExtraction did to a paper's sections
+type SectionChanges struct {
+	Kept     int `json:"kept"`
+	Inserted int `json:"inserted"`
+	Deleted  int `json:"deleted"`
+}
+
+// DiffSections matches the sections of a new extraction to the existing ones by text, in order.
+// Matched sections keep their ID (and embedding) and take the new header, order, place in the tree and coordinates,
+// the rest are inserted or deleted.
+func DiffSections(existing []Section, updated []Section) (kept []Section, inserted []Section, deleted []int64) {
+	byText := make(map[string][]Section)
+	for _, section := range existing {
+		byText[section.Text] = append(byText[section.Text], section)
+	}
+
+	matched := make(map[int64]bool)
+	for order, section := range updated {
+		section.Order = int64(order)
+		if matches := byText[section.Text]; len(matches) > 0 {
+			match := matches[0]
+			byText[section.Text] = matches[1:]
+			matched[match.ID] = true
+			match.Header = section.Header
+			match.Number = section.Number
+			match.Depth = section.Depth
+			match.ParentIndex = section.ParentIndex
+			match.Page = section.Page
+			match.Coords = section.Coords
+			match.Order = section.Order
+			kept = append(kept, match)
+			continue
+		}
+		inserted = append(inserted, section)
+	}
+
+	for _, section := range existing {
+		if !matched[section.ID] {
+			deleted = append(deleted, section.ID)
+		}
+	}
+	return kept, inserted, deleted
+}
+
+// ReplaceExtraction swaps everything extracted from a paper for a new extraction in one transaction, so a failure
+// leaves the paper as it was: its sections, abstract parts, authors, references, figures and citations. The ID of
+// each section is set in the slice.
+func (store *Store) ReplaceExtraction(paperID, screenID int64, sections []Section, extraction *parsing.PDFDTO) (SectionChanges, error) {
+	var changes SectionChanges
+	err := store.inTx(func(tx *sql.Tx) error {
+		ids, sectionChanges, err := replaceSections(tx, paperID, sections)
+		if err != nil {
+			return err
+		}
+		changes = sectionChanges
+		// citations need the IDs of the sections they are in
+		setSectionIDs(sections, ids)
+
+		if err := replaceAbstractParts(tx, paperID, extraction.AbstractParts); err != nil {
+			return err
+		}
+		if err := replacePaperAuthors(tx, paperID, screenID, extraction.Authors); err != nil {
+			return err
+		}
+		if err := replaceReferences(tx, paperID, extraction.References); err != nil {
+			return err
+		}
+		if err := replaceFigures(tx, paperID, extraction.Figures); err != nil {
+			return err
+		}
+		return replaceCitations(tx, paperID, sections)
+	})
+	if err != nil {
+		return SectionChanges{}, err
+	}
+	return changes, nil
+}
+
+// withText returns the sections that have text, and the index each of them had. A section under one without text
+// loses its parent, as it does when the worker creates sections one by one.
+func withText(sections []Section) ([]Section, []int) {
+	var filtered []Section
+	var indexes []int
+	newIndex := make(map[int]int)
+	for i, section := range sections {
+		if section.Text == "" {
+			continue
+		}
+		if section.ParentIndex != nil {
+			if parent, ok := newIndex[*section.ParentIndex]; ok {
+				section.ParentIndex = &parent
+			} else {
+				section.ParentIndex = nil
+			}
+		}
+		newIndex[i] = len(filtered)
+		filtered = append(filtered, section)
+		indexes = append(indexes, i)
+	}
+	return filtered, indexes
+}
+
+// setSectionIDs sets the IDs replaceSections returned, by index in the slice it was given
+func setSectionIDs(sections []Section, ids map[int]int64) {
+	for i := range sections {
+		sections[i].ID = ids[i]
+	}
+}
+
+// replaceSections swaps a paper's sections for a new extraction within tx, keeping the IDs of unchanged sections.
+// Sections without text are skipped, as CreateSection rejects them. The ID of each section with text is returned by
+// its index in the slice.
+func replaceSections(tx *sql.Tx, paperID int64, all []Section) (map[int]int64, SectionChanges, error) {
+	sections, indexes := withText(all)
+	existing, err := findSectionsByPaper(tx, paperID)
+	if err != nil {
+		return nil, SectionChanges{}, err
+	}
+	kept, inserted, deleted := DiffSections(existing, sections)
+	now := carbon.Now().DateTimeString()
+
+	// deletes go first so the orders they held are free for the sections that move
+	for _, id := range deleted {
+		if _, err := tx.Exec("DELETE FROM sections WHERE id = ?", id); err != nil {
+			return nil, SectionChanges{}, err
+		}
+	}
+
+	// rows are written in order so a parent, which always comes first, has an id when its children are written
+	rows := make([]Section, len(sections))
+	for _, section := range append(kept, inserted...) {
+		rows[section.Order] = section
+	}
+	ids := make([]int64, len(rows))
+	for i, section := range rows {
+		section.ParentSectionID = nil
+		if section.ParentIndex != nil && *section.ParentIndex < i {
+			section.ParentSectionID = &ids[*section.ParentIndex]
+		}
+
+		if section.ID != 0 {
+			_, err = tx.Exec("UPDATE sections SET header = ?, number = ?, depth = ?, parent_section_id = ?, `order` = ?, page = ?, coords = ?, updated_at = ? WHERE id = ?",
+				section.Header, section.Number, section.Depth, section.ParentSectionID, section.Order, section.Page, section.Coords, now, section.ID)
+			if err != nil {
+				return nil, SectionChanges{}, err
+			}
+			ids[i] = section.ID
+			continue
+		}
+		result, err := tx.Exec("INSERT INTO sections (paper_id, header, number, depth, parent_section_id, text, page, coords, `order`, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			paperID, section.Header, section.Number, section.Depth, section.ParentSectionID, section.Text, section.Page, section.Coords, section.Order, now, now)
+		if err != nil {
+			return nil, SectionChanges{}, err
+		}
+		if ids[i], err = result.LastInsertId(); err != nil {
+			return nil, SectionChanges{}, err
+		}
+	}
+
+	byIndex := make(map[int]int64, len(ids))
+	for i, id := range ids {
+		byIndex[indexes[i]] = id
+	}
+	return byIndex, SectionChanges{Kept: len(kept), Inserted: len(inserted), Deleted: len(deleted)}, nil
+}
+
+// FindSectionsByPaper returns the sections of a paper in order
+func (store *Store) FindSectionsByPaper(paperID int64) ([]Section, error) {
+	return findSectionsByPaper(store.db, paperID)
+}
+
+// querier is satisfied by *sql.DB and *sql.Tx
+type querier interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+func findSectionsByPaper(q querier, paperID int64) ([]Section, error) {
+	rows, err := q.Query("SELECT "+sectionColumns+" FROM sections WHERE paper_id = ? ORDER BY `order`", paperID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sections := []Section{}
+	for rows.Next() {
+		section, err := scanSection(rows)
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, section)
+	}
+	return sections, rows.Err()
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"errors"
+	"reflect"
+	"simple-go-app/internal/parsing"
+	"testing"
+)
+
+// test unchanged sections keep their IDs when a paper is reprocessed
+func TestDiffSections(t *testing.T) {
+	existing := []Section{
+		{ID: 1, Header: "abstract", Text: "Abstract", Order: 0},
+		{ID: 2, Header: "intro", Text: "Old paragraph", Order: 1},
+		{ID: 3, Header: "intro", Text: "Repeated", Order: 2},
+		{ID: 4, Header: "methods", Text: "Methods", Order: 3},
+	}
+	updated := []Section{
+		{Header: "abstract", Text: "Abstract"},
+		{Header: "introduction", Text: "Repeated"},
+		{Header: "introduction", Text: "Repeated"},
+		{Header: "introduction", Text: "New paragraph"},
+		{Header: "methods", Text: "Methods"},
+	}
+
+	kept, inserted, deleted := DiffSections(existing, updated)
+
+	wantKept := []Section{
+		{ID: 1, Header: "abstract", Text: "Abstract", Order: 0},
+		{ID: 3, Header: "introduction", Text: "Repeated", Order: 1},
+		{ID: 4, Header: "methods", Text: "Methods", Order: 4},
+	}
+	if !reflect.DeepEqual(kept, wantKept) {
+		t.Errorf("Unexpected kept sections: %+v", kept)
+	}
+
+	wantInserted := []Section{
+		{Header: "introduction", Text: "Repeated", Order: 2},
+		{Header: "introduction", Text: "New paragraph", Order: 3},
+	}
+	if !reflect.DeepEqual(inserted, wantInserted) {
+		t.Errorf("Unexpected inserted sections: %+v", inserted)
+	}
+
+	if !reflect.DeepEqual(deleted, []int64{2}) {
+		t.Errorf("Unexpected deleted sections: %v", deleted)
+	}
+}
+
+// test a reprocessed paper is replaced in one transaction, skipping sections without text
+func TestStore_ReplaceExtraction(t *testing.T) {
+	s, fake := newFakeStore(t)
+	intro, abstract := 1, 0
+	sections := []Section{
+		{Header: "abstract", Text: ""},
+		{Header: "introduction", Text: "Introduction"},
+		{Header: "introduction", Text: "A paragraph [1].", ParentIndex: &intro, Citations: []parsing.Citation{{Offset: 12, Marker: "[1]"}}},
+		{Header: "abstract", Text: "Under the empty abstract", ParentIndex: &abstract},
+	}
+
+	changes, err := s.ReplaceExtraction(5, 7, sections, &parsing.PDFDTO{References: []parsing.Reference{{TEIID: "b0"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes.Inserted != 3 {
+		t.Errorf("Expected the 3 sections with text to be inserted, got %+v", changes)
+	}
+	inserts := fake.matching("INSERT INTO sections")
+	if len(inserts) != 3 || inserts[1].args[4] == nil || inserts[2].args[4] != nil {
+		t.Errorf("Expected a section to lose a parent without text, got %+v", inserts)
+	}
+	if sections[0].ID != 0 || sections[2].ID == 0 {
+		t.Errorf("Unexpected section IDs %+v", sections)
+	}
+	if citations := fake.matching("INSERT INTO citations"); len(citations) != 1 || citations[0].args[0] != sections[2].ID {
+		t.Errorf("Expected the citation to be in its section, got %+v", citations)
+	}
+	if len(fake.matching("INSERT INTO `references`")) != 1 || !fake.committed {
+		t.Errorf("Expected the references to be written and the transaction committed")
+	}
+}
+
+// test a failure after the sections are written leaves the paper as it was
+func TestStore_ReplaceExtraction_Rollback(t *testing.T) {
+	s, fake := newFakeStore(t)
+	fake.fail("INSERT INTO figures", errors.New("data too long"))
+
+	_, err := s.ReplaceExtraction(5, 7, []Section{{Header: "abstract", Text: "Abstract"}}, &parsing.PDFDTO{Figures: []parsing.Figure{{Label: "Fig. 1"}}})
+	if err == nil {
+		t.Fatal("Expected the insert error")
+	}
+	if len(fake.matching("INSERT INTO sections")) != 1 || fake.committed || !fake.rolledBack {
+		t.Errorf("Expected the written sections to be rolled back")
+	}
+}
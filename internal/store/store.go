@@ -3,6 +3,7 @@ package store
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/uniplaces/carbon"
 	"log"
 	"simple-go-app/internal/helpers"
@@ -17,34 +18,76 @@ type Store struct {
 }
 
 type Paper struct {
-	ID        int64   `json:"id"`
-	Slug      string  `json:"slug"`
-	CustomKey *string `json:"custom_key,omitempty"`
-	ISSN      *string `json:"issn,omitempty"`
-	DOI       *string `json:"doi,omitempty"`
-	UserID    int64   `json:"user_id"`
-	ScreenID  int64   `json:"screen_id"`
-	PubMedID  *int    `json:"pubmed_id,omitempty"`
-	Title     string  `json:"title"`
-	Abstract  string  `json:"abstract"`
-	Journal   *string `json:"journal,omitempty"`
-	Year      *string `json:"year,omitempty"`
-	// Issue     *uint16 `json:"issue,omitempty"`
-	Notes     *string `json:"notes,omitempty"`
-	Keywords  *string `json:"keywords,omitempty"`
-	CreatedAt string  `json:"created_at"`
-	UpdatedAt string  `json:"updated_at"`
+	ID                  int64   `json:"id"`
+	Slug                string  `json:"slug"`
+	CustomKey           *string `json:"custom_key,omitempty"`
+	ISSN                *string `json:"issn,omitempty"`
+	EISSN               *string `json:"eissn,omitempty"`
+	DOI                 *string `json:"doi,omitempty"`
+	UserID              int64   `json:"user_id"`
+	ScreenID            int64   `json:"screen_id"`
+	PubMedID            *int    `json:"pubmed_id,omitempty"`
+	ArXivID             *string `json:"arxiv_id,omitempty"`
+	PMCID               *string `json:"pmcid,omitempty"`
+	PII                 *string `json:"pii,omitempty"`
+	ISBN                *string `json:"isbn,omitempty"`
+	Title               string  `json:"title"`
+	Abstract            string  `json:"abstract"`
+	Journal             *string `json:"journal,omitempty"`
+	JournalAbbreviation *string `json:"journal_abbreviation,omitempty"`
+	Volume              *string `json:"volume,omitempty"`
+	Issue               *string `json:"issue,omitempty"`
+	Pages               *string `json:"pages,omitempty"`
+	Publisher           *string `json:"publisher,omitempty"`
+	Year                *string `json:"year,omitempty"`
+	// PublishedDate is an ISO date, the first day of the month or year when PublishedDatePrecision is month or year
+	PublishedDate          *string `json:"published_date,omitempty"`
+	PublishedDatePrecision *string `json:"published_date_precision,omitempty"`
+	Notes                  *string `json:"notes,omitempty"`
+	Keywords               *string `json:"-"` // a JSON array, see KeywordList
+	CreatedAt              string  `json:"created_at"`
+	UpdatedAt              string  `json:"updated_at"`
 }
 
 type Section struct {
-	ID        int64   `json:"id"`
-	PaperID   int64   `json:"paper_id"`
-	Order     int64   `json:"order"`
-	Header    string  `json:"header"`
-	Text      string  `json:"text"`
-	Embedding *string `json:"embedding,omitempty"`
-	CreatedAt string  `json:"created_at"`
-	UpdatedAt string  `json:"updated_at"`
+	ID              int64              `json:"id"`
+	PaperID         int64              `json:"paper_id"`
+	Order           int64              `json:"order"`
+	Header          string             `json:"header"`
+	Number          *string            `json:"number,omitempty"`
+	Depth           int64              `json:"depth"`
+	ParentSectionID *int64             `json:"parent_section_id,omitempty"` // the first row of the enclosing section
+	ParentIndex     *int               `json:"-"`                           // the parent's position in the slice given to ReplaceExtraction, before its id is known
+	Text            string             `json:"text"`
+	Page            *int64             `json:"page,omitempty"`
+	Coords          *string            `json:"-"` // a JSON array of boxes, see BoxList
+	Citations       []parsing.Citation `json:"-"` // written by ReplaceCitations
+	Embedding       *string            `json:"embedding,omitempty"`
+	CreatedAt       string             `json:"created_at"`
+	UpdatedAt       string             `json:"updated_at"`
+}
+
+// paperColumns and sectionColumns are selected instead of * so adding a column to the tables does not break scanning
+const (
+	paperColumns   = "id, slug, custom_key, issn, eissn, doi, user_id, screen_id, title, abstract, journal, journal_abbreviation, volume, issue, pages, publisher, year, published_date, published_date_precision, notes, pubmed_id, arxiv_id, pmcid, pii, isbn, keywords, created_at, updated_at"
+	sectionColumns = "id, paper_id, `order`, header, number, depth, parent_section_id, text, page, coords, embedding, created_at, updated_at"
+)
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPaper(row rowScanner) (Paper, error) {
+	var paper Paper
+	err := row.Scan(&paper.ID, &paper.Slug, &paper.CustomKey, &paper.ISSN, &paper.EISSN, &paper.DOI, &paper.UserID, &paper.ScreenID, &paper.Title, &paper.Abstract, &paper.Journal, &paper.JournalAbbreviation, &paper.Volume, &paper.Issue, &paper.Pages, &paper.Publisher, &paper.Year, &paper.PublishedDate, &paper.PublishedDatePrecision, &paper.Notes, &paper.PubMedID, &paper.ArXivID, &paper.PMCID, &paper.PII, &paper.ISBN, &paper.Keywords, &paper.CreatedAt, &paper.UpdatedAt)
+	return paper, err
+}
+
+func scanSection(row rowScanner) (Section, error) {
+	var section Section
+	err := row.Scan(&section.ID, &section.PaperID, &section.Order, &section.Header, &section.Number, &section.Depth, &section.ParentSectionID, &section.Text, &section.Page, &section.Coords, &section.Embedding, &section.CreatedAt, &section.UpdatedAt)
+	return section, err
 }
 
 type Screen struct {
@@ -59,12 +102,14 @@ type Screen struct {
 
 // Log represents a log entry to be saved in the database
 type Log struct {
-	Level       string
-	UserMessage string
-	FullLog     string
-	Stage       string
-	UserID      int64
-	ScreenID    int64
+	ID          int64  `json:"id"`
+	Level       string `json:"level"`
+	UserMessage string `json:"user_message"`
+	FullLog     string `json:"full_log"`
+	Stage       string `json:"stage"`
+	UserID      int64  `json:"user_id"`
+	ScreenID    int64  `json:"screen_id"`
+	CreatedAt   string `json:"created_at"`
 }
 
 // New creates a new Store instance
@@ -77,6 +122,20 @@ func (store *Store) GetDB() *sql.DB {
 	return store.db
 }
 
+// inTx runs fn in a transaction, committing it when fn succeeds
+func (store *Store) inTx(fn func(tx *sql.Tx) error) error {
+	tx, err := store.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
 func (store *Store) FindDOIFromPaperRepository(pdfdto *parsing.PDFDTO, screenID int64) {
 	paper, err := store.FindPaperByTitleAndAbstract(screenID, pdfdto.Title, pdfdto.Abstract)
 	if err != nil {
@@ -106,29 +165,14 @@ func (store *Store) FindPaperByTitleAndAbstract(screenID int64, title string, ab
 	var papers []Paper
 
 	// there should only be one paper with the same title and abstract but we will handle the case where there are multiple and log it
-	rows, err := store.db.Query("SELECT * FROM papers WHERE screen_id = ? AND title = ? AND abstract = ?", screenID, title, abstract)
+	rows, err := store.db.Query("SELECT "+paperColumns+" FROM papers WHERE screen_id = ? AND title = ? AND abstract = ?", screenID, title, abstract)
 	if err != nil {
 		return Paper{}, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
-		var paper Paper
-		err = rows.Scan(
-			&paper.ID,
-			&paper.Slug,
-			&paper.CustomKey,
-			&paper.ISSN,
-			&paper.DOI,
-			&paper.UserID,
-			&paper.ScreenID,
-			&paper.Title,
-			&paper.Abstract,
-			&paper.Journal,
-			&paper.Year,
-			&paper.Notes,
-			&paper.PubMedID,
-			&paper.CreatedAt,
-			&paper.UpdatedAt)
+		paper, err := scanPaper(rows)
 		if err != nil {
 			return Paper{}, err
 		}
@@ -153,14 +197,14 @@ func (store *Store) FindPaperByTitle(screenID int64, title string) (Paper, error
 	var papers []Paper
 
 	// there should only be one paper with the same title and abstract but we will handle the case where there are multiple and log it
-	rows, err := store.db.Query("SELECT * FROM papers WHERE screen_id = ? AND title = ?", screenID, title)
+	rows, err := store.db.Query("SELECT "+paperColumns+" FROM papers WHERE screen_id = ? AND title = ?", screenID, title)
 	if err != nil {
 		return Paper{}, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
-		var paper Paper
-		err = rows.Scan(&paper.ID, &paper.Slug, &paper.CustomKey, &paper.ISSN, &paper.DOI, &paper.UserID, &paper.ScreenID, &paper.Title, &paper.Abstract, &paper.Journal, &paper.Year, &paper.Notes, &paper.PubMedID, &paper.CreatedAt, &paper.UpdatedAt)
+		paper, err := scanPaper(rows)
 		if err != nil {
 			return Paper{}, err
 		}
@@ -182,8 +226,7 @@ func (store *Store) FindPaperByTitle(screenID int64, title string) (Paper, error
 }
 
 func (store *Store) FindPaperByDOI(id int64, doi string) (Paper, error) {
-	var paper Paper
-	err := store.db.QueryRow("SELECT * FROM papers WHERE screen_id = ? AND doi = ?", id, doi).Scan(&paper.ID, &paper.Slug, &paper.CustomKey, &paper.ISSN, &paper.DOI, &paper.UserID, &paper.ScreenID, &paper.Title, &paper.Abstract, &paper.Journal, &paper.Year, &paper.Notes, &paper.PubMedID, &paper.CreatedAt, &paper.UpdatedAt)
+	paper, err := scanPaper(store.db.QueryRow("SELECT "+paperColumns+" FROM papers WHERE screen_id = ? AND doi = ?", id, doi))
 	if err != nil {
 		return Paper{}, err
 	}
@@ -212,13 +255,25 @@ func (store *Store) CreatePaper(dto *parsing.PDFDTO, userID int64, screenID int6
 	slug := helpers.GenerateRandomString(14)
 
 	// create paper
-	_, err := store.db.Exec("INSERT INTO papers (slug, user_id, screen_id, pubmed_id, title, issn, abstract, year, doi, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
-		slug, userID, screenID, dto.PubMedID, dto.Title, dto.ISSN, dto.Abstract, dto.Year, dto.DOI, carbon.Now().DateTimeString(), carbon.Now().DateTimeString())
+	keywords, err := encodeKeywords(dto.Keywords)
+	if err != nil {
+		return Paper{}, err
+	}
+	publishedDate, precision := encodePublishedDate(dto.PublishedDate)
+	result, err := store.db.Exec("INSERT INTO papers (slug, user_id, screen_id, pubmed_id, arxiv_id, pmcid, pii, isbn, title, issn, eissn, abstract, journal, journal_abbreviation, volume, issue, pages, publisher, year, published_date, published_date_precision, notes, doi, keywords, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		slug, userID, screenID, dto.PubMedID, nullString(dto.Identifiers.ArXiv), nullString(dto.Identifiers.PMCID), nullString(dto.Identifiers.PII), nullString(dto.Identifiers.ISBN), dto.Title, dto.ISSN, nullString(dto.EISSN), dto.Abstract, nullString(dto.Journal), nullString(dto.JournalAbbreviation), nullString(dto.Volume), nullString(dto.Issue), nullString(dto.Pages), nullString(dto.Publisher), dto.Year, publishedDate, precision, nullString(dto.Notes), dto.DOI, keywords, carbon.Now().DateTimeString(), carbon.Now().DateTimeString())
 	if err != nil {
 		return Paper{}, err
 	}
-	// grab paper by doi
-	paper, _ := store.FindPaperByDOI(screenID, dto.DOI)
+	// read back by id, as a paper without a DOI would match every other one of the screen by DOI
+	id, err := result.LastInsertId()
+	if err != nil {
+		return Paper{}, err
+	}
+	paper, err := store.FindPaperByID(id)
+	if err != nil {
+		return Paper{}, fmt.Errorf("reading created paper %d: %w", id, err)
+	}
 
 	return paper, nil
 }
@@ -232,7 +287,8 @@ func (store *Store) GetNextSectionOrder(paperID int64) (int, interface{}) {
 	return order, nil
 }
 
-func (store *Store) CreateSection(paperID int64, header string, text string, order int) (interface{}, interface{}) {
+func (store *Store) CreateSection(paperID int64, newSection Section, order int) (interface{}, interface{}) {
+	header, text := newSection.Header, newSection.Text
 
 	// validate inputs
 	if header == "" {
@@ -251,7 +307,8 @@ func (store *Store) CreateSection(paperID int64, header string, text string, ord
 		return section, nil
 	}
 
-	_, err = store.db.Exec("INSERT INTO sections (paper_id, header, text, `order`, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)", paperID, header, text, order, carbon.Now().DateTimeString(), carbon.Now().DateTimeString())
+	_, err = store.db.Exec("INSERT INTO sections (paper_id, header, number, depth, parent_section_id, text, page, coords, `order`, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		paperID, header, newSection.Number, newSection.Depth, newSection.ParentSectionID, text, newSection.Page, newSection.Coords, order, carbon.Now().DateTimeString(), carbon.Now().DateTimeString())
 	if err != nil {
 		return section, err
 	}
@@ -262,8 +319,7 @@ func (store *Store) CreateSection(paperID int64, header string, text string, ord
 }
 
 func (store *Store) FindSectionByPaperAndPosition(paperID int64, position int) (Section, interface{}) {
-	var section Section
-	err := store.db.QueryRow("SELECT * FROM sections WHERE paper_id = ? AND `order` = ?", paperID, position).Scan(&section.ID, &section.PaperID, &section.Order, &section.Header, &section.Text, &section.Embedding, &section.CreatedAt, &section.UpdatedAt)
+	section, err := scanSection(store.db.QueryRow("SELECT "+sectionColumns+" FROM sections WHERE paper_id = ? AND `order` = ?", paperID, position))
 	if err != nil {
 		return Section{}, err
 	}
@@ -271,8 +327,7 @@ func (store *Store) FindSectionByPaperAndPosition(paperID int64, position int) (
 }
 
 func (store *Store) FindSectionByHeaderAndText(paperID int64, header string, text string) (Section, interface{}) {
-	var section Section
-	err := store.db.QueryRow("SELECT * FROM sections WHERE paper_id = ? AND header = ? AND text = ?", paperID, header, text).Scan(&section.ID, &section.PaperID, &section.Order, &section.Header, &section.Text, &section.Embedding, &section.CreatedAt, &section.UpdatedAt)
+	section, err := scanSection(store.db.QueryRow("SELECT "+sectionColumns+" FROM sections WHERE paper_id = ? AND header = ? AND text = ?", paperID, header, text))
 	if err != nil {
 		//log.Printf("Error finding section by header and text: %v\n", err)
 		return Section{}, err
@@ -0,0 +1,156 @@
+package uploads
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// Reasons an entry of an archive is rejected
+const (
+	ReasonNotPDF     = "not a pdf"
+	ReasonEncrypted  = "encrypted"
+	ReasonDuplicate  = "duplicate"
+	ReasonTooLarge   = "too large"
+	ReasonUnreadable = "unreadable"
+)
+
+var (
+	// ErrArchiveTooLarge is returned when an archive is bigger than Limits.MaxArchiveBytes
+	ErrArchiveTooLarge = errors.New("archive is too large")
+	// ErrTooManyEntries is returned when an archive has more files than Limits.MaxEntries
+	ErrTooManyEntries = errors.New("archive has too many entries")
+)
+
+// Limits bounds the size of an archive and what it may contain
+type Limits struct {
+	MaxArchiveBytes int64
+	MaxEntries      int
+	MaxEntryBytes   int64
+}
+
+// PDF is an entry of an archive that passed the checks
+type PDF struct {
+	Name    string
+	SHA256  string
+	Content []byte
+}
+
+// Rejection is an entry of an archive that will not be processed
+type Rejection struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+	// DuplicateOf is the entry with the same content when Reason is ReasonDuplicate
+	DuplicateOf string `json:"duplicate_of,omitempty"`
+}
+
+// Spool copies an archive to a temporary file so its central directory can be read, which is at the end of a ZIP.
+// The caller removes the file once done with it.
+func Spool(r io.Reader, limits Limits) (*os.File, int64, error) {
+	file, err := os.CreateTemp("", "upload-*.zip")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	size, err := io.Copy(file, io.LimitReader(r, limits.MaxArchiveBytes+1))
+	if err == nil && size > limits.MaxArchiveBytes {
+		err = ErrArchiveTooLarge
+	}
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, 0, err
+	}
+	return file, size, nil
+}
+
+// Extract calls fn with each PDF in the archive, one at a time so only one is held in memory.
+// Entries that are not PDFs, are encrypted, are too large or repeat an earlier entry are returned as rejections.
+// An error from fn stops the extraction.
+func Extract(r io.ReaderAt, size int64, limits Limits, fn func(pdf PDF) error) ([]Rejection, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*zip.File
+	for _, f := range archive.File {
+		if !ignored(f) {
+			files = append(files, f)
+		}
+	}
+	if len(files) > limits.MaxEntries {
+		return nil, fmt.Errorf("%w: %d, the limit is %d", ErrTooManyEntries, len(files), limits.MaxEntries)
+	}
+
+	rejected := []Rejection{}
+	seen := make(map[string]string)
+	for _, f := range files {
+		content, reason := read(f, limits.MaxEntryBytes)
+		if reason != "" {
+			rejected = append(rejected, Rejection{Name: f.Name, Reason: reason})
+			continue
+		}
+
+		sum := sha256.Sum256(content)
+		hash := hex.EncodeToString(sum[:])
+		if original, ok := seen[hash]; ok {
+			rejected = append(rejected, Rejection{Name: f.Name, Reason: ReasonDuplicate, DuplicateOf: original})
+			continue
+		}
+		seen[hash] = f.Name
+
+		if err := fn(PDF{Name: f.Name, SHA256: hash, Content: content}); err != nil {
+			return rejected, err
+		}
+	}
+	return rejected, nil
+}
+
+// read checks an entry is an unencrypted PDF within the size limit and returns its content
+func read(f *zip.File, maxBytes int64) ([]byte, string) {
+	if !strings.EqualFold(path.Ext(f.Name), ".pdf") {
+		return nil, ReasonNotPDF
+	}
+	// bit 0 of the general purpose flags marks an encrypted entry
+	if f.Flags&0x1 != 0 {
+		return nil, ReasonEncrypted
+	}
+	if f.UncompressedSize64 > uint64(maxBytes) {
+		return nil, ReasonTooLarge
+	}
+
+	entry, err := f.Open()
+	if err != nil {
+		return nil, ReasonUnreadable
+	}
+	defer entry.Close()
+
+	// the declared size can lie, so the limit is enforced on what is actually inflated
+	content, err := io.ReadAll(io.LimitReader(entry, maxBytes+1))
+	if err != nil {
+		return nil, ReasonUnreadable
+	}
+	if int64(len(content)) > maxBytes {
+		return nil, ReasonTooLarge
+	}
+	if !bytes.HasPrefix(content, []byte("%PDF-")) {
+		return nil, ReasonNotPDF
+	}
+	if bytes.Contains(content, []byte("/Encrypt")) {
+		return nil, ReasonEncrypted
+	}
+	return content, ""
+}
+
+// ignored skips directories and the metadata macOS adds to archives it creates
+func ignored(f *zip.File) bool {
+	return f.FileInfo().IsDir() || strings.HasPrefix(f.Name, "__MACOSX/") || strings.HasPrefix(path.Base(f.Name), "._")
+}
@@ -0,0 +1,88 @@
+package uploads
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newArchive(t *testing.T, files map[string]string, names ...string) *bytes.Reader {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range names {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(files[name]))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestExtract(t *testing.T) {
+	files := map[string]string{
+		"a.pdf":            "%PDF-1.7 a",
+		"papers/b.PDF":     "%PDF-1.7 b",
+		"copy of a.pdf":    "%PDF-1.7 a",
+		"notes.txt":        "not a paper",
+		"fake.pdf":         "<html>",
+		"locked.pdf":       "%PDF-1.7 trailer << /Encrypt 5 0 R >>",
+		"big.pdf":          "%PDF-1.7 " + strings.Repeat("x", 100),
+		"__MACOSX/._a.pdf": "resource fork",
+	}
+	names := []string{"a.pdf", "papers/", "papers/b.PDF", "copy of a.pdf", "notes.txt", "fake.pdf", "locked.pdf", "big.pdf", "__MACOSX/._a.pdf"}
+	archive := newArchive(t, files, names...)
+
+	var extracted []string
+	rejected, err := Extract(archive, archive.Size(), Limits{MaxEntries: 10, MaxEntryBytes: 50}, func(pdf PDF) error {
+		extracted = append(extracted, pdf.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Join(extracted, ",") != "a.pdf,papers/b.PDF" {
+		t.Errorf("Unexpected PDFs: %v", extracted)
+	}
+
+	want := map[string]string{
+		"copy of a.pdf": ReasonDuplicate,
+		"notes.txt":     ReasonNotPDF,
+		"fake.pdf":      ReasonNotPDF,
+		"locked.pdf":    ReasonEncrypted,
+		"big.pdf":       ReasonTooLarge,
+	}
+	if len(rejected) != len(want) {
+		t.Errorf("Expected %d rejections, got %+v", len(want), rejected)
+	}
+	for _, r := range rejected {
+		if want[r.Name] != r.Reason {
+			t.Errorf("%s: expected %q, got %q", r.Name, want[r.Name], r.Reason)
+		}
+		if r.Reason == ReasonDuplicate && r.DuplicateOf != "a.pdf" {
+			t.Errorf("Expected duplicate of a.pdf, got %q", r.DuplicateOf)
+		}
+	}
+}
+
+func TestExtract_TooManyEntries(t *testing.T) {
+	files := map[string]string{"a.pdf": "%PDF-", "b.pdf": "%PDF-"}
+	archive := newArchive(t, files, "a.pdf", "b.pdf")
+
+	_, err := Extract(archive, archive.Size(), Limits{MaxEntries: 1, MaxEntryBytes: 50}, func(PDF) error { return nil })
+	if !errors.Is(err, ErrTooManyEntries) {
+		t.Errorf("Expected ErrTooManyEntries, got %v", err)
+	}
+}
+
+func TestSpool_TooLarge(t *testing.T) {
+	if _, _, err := Spool(strings.NewReader("0123456789"), Limits{MaxArchiveBytes: 5}); !errors.Is(err, ErrArchiveTooLarge) {
+		t.Errorf("Expected ErrArchiveTooLarge, got %v", err)
+	}
+}
@@ -1,16 +1,25 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	_ "github.com/go-sql-driver/mysql"
 	"log"
 	"net/http"
 	"os"
+	"simple-go-app/internal/api"
+	"simple-go-app/internal/archive"
+	"simple-go-app/internal/auth"
+	"simple-go-app/internal/grobidcache"
+	"simple-go-app/internal/health"
 	"simple-go-app/internal/helpers"
+	"simple-go-app/internal/jobs"
 	"simple-go-app/internal/logging"
+	"simple-go-app/internal/metrics"
 	"simple-go-app/internal/parsing"
 	"simple-go-app/internal/store"
+	"simple-go-app/internal/uploads"
 	"strconv"
 	"sync"
 	"time"
@@ -18,8 +27,10 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"simple-go-app/internal/dispatcher"
 )
@@ -76,12 +87,11 @@ func main() {
 
 	// Set up the queue service
 	sqsSvc := sqs.New(sess)
+	s3Svc := s3.New(sess)
 
-	// Create a channel for communication between dispatcher and workers
-	messageQueue := make(chan *sqs.Message, 10) // Adjust the buffer size as needed
-
-	// Start dispatcher
-	go dispatcher.Dispatcher(sqsSvc, sqsURL, messageQueue)
+	// Keep track of the jobs flowing through this instance for the progress API
+	jobRetention := time.Duration(helpers.GetEnvIntDefault("JOB_RETENTION_MINUTES", 24*60)) * time.Minute
+	tracker := jobs.NewTracker(jobRetention)
 
 	// set up cache service
 	cacheSvc, err := helpers.NewCacheHelper(sess, cacheTableName)
@@ -89,14 +99,40 @@ func main() {
 		log.Fatal("Error creating cache service:", err)
 	}
 
-	workFunc := func() {
-		numWorkers, _ := strconv.Atoi(helpers.GetEnvVariable("WORKER_COUNT"))
-		// Start three workers
-		for i := 1; i <= numWorkers; i++ {
-			go dispatcher.Worker(i, messageQueue, sqsSvc, sqsURL, awsBucket, s, cacheSvc)
-		}
+	var grobid parsing.Grobid = parsing.NewGrobidClientFromEnv()
+
+	// Reuse the TEI of PDFs that were already processed, such as one uploaded to several screens
+	grobidCache, err := grobidcache.NewFromEnv(sess, s3Svc, awsBucket)
+	if err != nil {
+		log.Fatal("Error setting up the Grobid cache:", err)
+	}
+	if grobidCache != nil {
+		grobid = grobidcache.Wrap(grobid, grobidCache)
+	}
+
+	// Keep the TEI of every paper so the parser can be replayed without Grobid
+	teiArchive, err := archive.NewFromEnv(s3Svc, awsBucket)
+	if err != nil {
+		log.Fatal("Error setting up the archive:", err)
 	}
 
+	// The pool owns the dispatcher and workers, it is started once Grobid is healthy
+	pool := dispatcher.NewPool(dispatcher.Config{
+		SQS:       sqsSvc,
+		SQSURL:    sqsURL,
+		S3Bucket:  awsBucket,
+		AWSRegion: awsRegion,
+		Store:     s,
+		Cache:     cacheSvc,
+		Tracker:   tracker,
+		Grobid:    grobid,
+		Archive:   teiArchive,
+	})
+	workFunc := pool.Start
+
+	// Poll the queue size for the metrics endpoint
+	go metrics.WatchQueueDepth(sqsSvc, sqsURL, 30*time.Second)
+
 	// Start a timer for periodic health checks
 	go func() {
 		// Introduce a 15-second delay before updating healthStatus to true
@@ -105,25 +141,89 @@ func main() {
 		startDelayInt, _ := strconv.Atoi(startDelay)
 		time.Sleep(time.Duration(startDelayInt) * time.Second)
 
-		parsing.CheckGrobidHealth(&healthStatus, &healthMutex, workFunc)
+		parsing.CheckGrobidHealth(grobid, &healthStatus, &healthMutex, workFunc)
 		for {
 			// this is backup if server doesn't shutdown on bad response
 			time.Sleep(1 * time.Minute) // Adjust the interval as needed
-			parsing.CheckGrobidHealth(&healthStatus, &healthMutex)
+			parsing.CheckGrobidHealth(grobid, &healthStatus, &healthMutex)
 		}
 	}()
 
+	// Every route needs a key with the right scope unless it is listed in PUBLIC_ENDPOINTS
+	authenticator, err := auth.New(
+		helpers.GetEnvVariableDefault("API_KEYS", ""),
+		helpers.GetEnvVariableDefault("HMAC_KEYS", ""),
+		helpers.GetEnvVariableDefault("PUBLIC_ENDPOINTS", "/,/health,/livez,/readyz,/metrics"),
+	)
+	if err != nil {
+		log.Fatal("Error configuring authentication:", err)
+	}
+	if !authenticator.HasKeys() {
+		logging.WarningLogger.Println("API_KEYS and HMAC_KEYS not set, only public endpoints can be used")
+	}
+
 	r := gin.Default()
 
-	r.GET("/", func(c *gin.Context) {
+	ops := r.Group("", authenticator.Require(auth.ScopeRead))
+
+	ops.GET("/", func(c *gin.Context) {
 		host, _ := os.Hostname()
 		c.JSON(http.StatusOK, gin.H{"hostname": host})
 	})
 
-	r.GET("/health", func(c *gin.Context) {
+	ops.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	ops.GET("/health", func(c *gin.Context) {
 		// Return the global health status
-		c.JSON(http.StatusOK, gin.H{"healthy": healthStatus})
+		healthMutex.Lock()
+		healthy := healthStatus
+		healthMutex.Unlock()
+		c.JSON(http.StatusOK, gin.H{"healthy": healthy})
+	})
+
+	// Dependencies checked by /readyz
+	readiness := health.New(time.Duration(helpers.GetEnvIntDefault("READINESS_TIMEOUT_SECONDS", 5)) * time.Second)
+	readiness.Add("grobid", grobid.IsAlive)
+	readiness.Add("mysql", health.MySQL(s.GetDB()))
+	readiness.Add("sqs", health.SQSQueue(sqsSvc, sqsURL))
+	readiness.Add("dynamodb", cacheSvc.Ping)
+	readiness.Add("s3", health.S3Bucket(s3Svc, awsBucket))
+	readiness.Add("workers", func(ctx context.Context) error {
+		if state := pool.State(); state != dispatcher.StateRunning {
+			return fmt.Errorf("worker pool is %s", state)
+		}
+		return nil
 	})
 
+	// a ticker panics on a zero or negative interval, so log tails poll at least once a second
+	logPollSeconds := helpers.GetEnvIntDefault("LOG_TAIL_POLL_SECONDS", 2)
+	if logPollSeconds < 1 {
+		logPollSeconds = 1
+	}
+
+	server := &api.Server{
+		Tracker:   tracker,
+		Cache:     cacheSvc,
+		Pool:      pool,
+		Store:     s,
+		Grobid:    grobid,
+		Auth:      authenticator,
+		Readiness: readiness,
+
+		StuckWorkerTimeout: time.Duration(helpers.GetEnvIntDefault("STUCK_WORKER_TIMEOUT_SECONDS", 900)) * time.Second,
+		LogPollInterval:    time.Duration(logPollSeconds) * time.Second,
+		MaxUploadBytes:     int64(helpers.GetEnvIntDefault("PARSE_MAX_UPLOAD_MB", 100)) << 20,
+
+		S3:           s3Svc,
+		S3Bucket:     awsBucket,
+		UploadPrefix: helpers.GetEnvVariableDefault("UPLOAD_PREFIX", "uploads/"),
+		UploadLimits: uploads.Limits{
+			MaxArchiveBytes: int64(helpers.GetEnvIntDefault("UPLOAD_MAX_ARCHIVE_MB", 1024)) << 20,
+			MaxEntries:      helpers.GetEnvIntDefault("UPLOAD_MAX_ENTRIES", 500),
+			MaxEntryBytes:   int64(helpers.GetEnvIntDefault("UPLOAD_MAX_PDF_MB", 100)) << 20,
+		},
+	}
+	server.Register(r)
+
 	r.Run()
 }
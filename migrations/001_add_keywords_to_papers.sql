@@ -0,0 +1,2 @@
+-- Keywords extracted by Grobid, stored as a JSON array
+ALTER TABLE papers ADD COLUMN keywords TEXT NULL AFTER pubmed_id;
@@ -0,0 +1,18 @@
+-- Bibliography entries Grobid extracts from each paper. `references` is a reserved word in MySQL and must be quoted.
+CREATE TABLE `references` (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    paper_id BIGINT UNSIGNED NOT NULL,
+    `order` INT UNSIGNED NOT NULL,
+    tei_id VARCHAR(32) NULL,
+    title TEXT NULL,
+    authors TEXT NULL,
+    year VARCHAR(4) NULL,
+    venue TEXT NULL,
+    doi VARCHAR(255) NULL,
+    raw TEXT NULL,
+    created_at TIMESTAMP NULL,
+    updated_at TIMESTAMP NULL,
+    INDEX references_paper_id_order_index (paper_id, `order`),
+    INDEX references_doi_index (doi),
+    CONSTRAINT references_paper_id_foreign FOREIGN KEY (paper_id) REFERENCES papers (id) ON DELETE CASCADE
+);
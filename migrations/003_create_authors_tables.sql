@@ -0,0 +1,30 @@
+-- Authors Grobid extracts from paper headers. An author is shared between the papers of a screen when the ORCID or
+-- email matches, screens belong to different users and never share authors.
+CREATE TABLE authors (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    screen_id BIGINT UNSIGNED NOT NULL,
+    forename VARCHAR(255) NULL,
+    surname VARCHAR(255) NULL,
+    email VARCHAR(255) NULL,
+    orcid VARCHAR(19) NULL,
+    created_at TIMESTAMP NULL,
+    updated_at TIMESTAMP NULL,
+    INDEX authors_screen_id_orcid_index (screen_id, orcid),
+    INDEX authors_screen_id_email_index (screen_id, email),
+    CONSTRAINT authors_screen_id_foreign FOREIGN KEY (screen_id) REFERENCES screens (id) ON DELETE CASCADE
+);
+
+-- Affiliations belong to the paper, an author's institution changes over time. They are stored as a JSON array.
+CREATE TABLE paper_authors (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    paper_id BIGINT UNSIGNED NOT NULL,
+    author_id BIGINT UNSIGNED NOT NULL,
+    `order` INT UNSIGNED NOT NULL,
+    corresponding TINYINT(1) NOT NULL DEFAULT 0,
+    affiliations TEXT NULL,
+    created_at TIMESTAMP NULL,
+    updated_at TIMESTAMP NULL,
+    INDEX paper_authors_paper_id_order_index (paper_id, `order`),
+    CONSTRAINT paper_authors_paper_id_foreign FOREIGN KEY (paper_id) REFERENCES papers (id) ON DELETE CASCADE,
+    CONSTRAINT paper_authors_author_id_foreign FOREIGN KEY (author_id) REFERENCES authors (id) ON DELETE CASCADE
+);
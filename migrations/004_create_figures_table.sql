@@ -0,0 +1,15 @@
+-- Figures and tables Grobid extracts from each paper. The cells of a table are stored as a JSON array of rows.
+CREATE TABLE figures (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    paper_id BIGINT UNSIGNED NOT NULL,
+    `order` INT UNSIGNED NOT NULL,
+    tei_id VARCHAR(32) NULL,
+    type VARCHAR(16) NOT NULL,
+    label VARCHAR(255) NULL,
+    caption TEXT NULL,
+    cells MEDIUMTEXT NULL,
+    created_at TIMESTAMP NULL,
+    updated_at TIMESTAMP NULL,
+    INDEX figures_paper_id_order_index (paper_id, `order`),
+    CONSTRAINT figures_paper_id_foreign FOREIGN KEY (paper_id) REFERENCES papers (id) ON DELETE CASCADE
+);
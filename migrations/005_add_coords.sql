@@ -0,0 +1,5 @@
+-- PDF coordinates from Grobid, stored as JSON arrays of page, x, y, width and height boxes, so the front end can highlight
+-- a section, figure or reference in the PDF. page is the page a section starts on.
+ALTER TABLE sections ADD COLUMN page INT UNSIGNED NULL AFTER text, ADD COLUMN coords TEXT NULL AFTER page;
+ALTER TABLE figures ADD COLUMN coords TEXT NULL AFTER cells;
+ALTER TABLE `references` ADD COLUMN coords TEXT NULL AFTER raw;
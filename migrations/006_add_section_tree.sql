@@ -0,0 +1,7 @@
+-- The section tree. Rows are paragraphs, a row points to the first row of the section enclosing its own and depth is 1
+-- for top level sections. number is the printed section number such as 2.3.1.
+ALTER TABLE sections
+    ADD COLUMN number VARCHAR(32) NULL AFTER header,
+    ADD COLUMN depth TINYINT UNSIGNED NOT NULL DEFAULT 1 AFTER number,
+    ADD COLUMN parent_section_id BIGINT UNSIGNED NULL AFTER depth,
+    ADD CONSTRAINT sections_parent_section_id_foreign FOREIGN KEY (parent_section_id) REFERENCES sections (id) ON DELETE SET NULL;
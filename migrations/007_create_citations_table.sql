@@ -0,0 +1,16 @@
+-- Inline citation markers taken out of section texts. offset is in characters of the section text, target is the TEI id
+-- of the cited reference and reference_id the row it was matched to, NULL when Grobid could not match the marker.
+CREATE TABLE citations (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    section_id BIGINT UNSIGNED NOT NULL,
+    reference_id BIGINT UNSIGNED NULL,
+    `offset` INT UNSIGNED NOT NULL,
+    marker VARCHAR(255) NULL,
+    target VARCHAR(32) NULL,
+    created_at TIMESTAMP NULL,
+    updated_at TIMESTAMP NULL,
+    INDEX citations_section_id_index (section_id),
+    INDEX citations_reference_id_index (reference_id),
+    CONSTRAINT citations_section_id_foreign FOREIGN KEY (section_id) REFERENCES sections (id) ON DELETE CASCADE,
+    CONSTRAINT citations_reference_id_foreign FOREIGN KEY (reference_id) REFERENCES `references` (id) ON DELETE SET NULL
+);
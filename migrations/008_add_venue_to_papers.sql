@@ -0,0 +1,12 @@
+-- Venue metadata and the publication date from the TEI header. papers already has journal, issn and notes, the
+-- sidecar now fills them too. The date is stored as the first day of what is known, published_date_precision says
+-- whether the year, the month or the day was printed.
+ALTER TABLE papers
+    ADD COLUMN journal_abbreviation VARCHAR(255) NULL AFTER journal,
+    ADD COLUMN eissn VARCHAR(9) NULL AFTER issn,
+    ADD COLUMN volume VARCHAR(32) NULL AFTER journal_abbreviation,
+    ADD COLUMN issue VARCHAR(32) NULL AFTER volume,
+    ADD COLUMN pages VARCHAR(32) NULL AFTER issue,
+    ADD COLUMN publisher VARCHAR(255) NULL AFTER pages,
+    ADD COLUMN published_date DATE NULL AFTER year,
+    ADD COLUMN published_date_precision VARCHAR(5) NULL AFTER published_date;
@@ -0,0 +1,13 @@
+-- The labelled parts of structured abstracts, such as Background or Methods, for screening. papers.abstract keeps the
+-- whole abstract as text. An unstructured abstract is a single part with a NULL label.
+CREATE TABLE abstract_parts (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    paper_id BIGINT UNSIGNED NOT NULL,
+    `order` INT UNSIGNED NOT NULL,
+    label VARCHAR(255) NULL,
+    text TEXT NOT NULL,
+    created_at TIMESTAMP NULL,
+    updated_at TIMESTAMP NULL,
+    INDEX abstract_parts_paper_id_order_index (paper_id, `order`),
+    CONSTRAINT abstract_parts_paper_id_foreign FOREIGN KEY (paper_id) REFERENCES papers (id) ON DELETE CASCADE
+);
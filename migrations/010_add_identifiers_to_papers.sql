@@ -0,0 +1,6 @@
+-- Identifiers from the TEI header and the first page, besides doi and pubmed_id which papers already has.
+ALTER TABLE papers
+    ADD COLUMN arxiv_id VARCHAR(32) NULL AFTER pubmed_id,
+    ADD COLUMN pmcid VARCHAR(16) NULL AFTER arxiv_id,
+    ADD COLUMN pii VARCHAR(32) NULL AFTER pmcid,
+    ADD COLUMN isbn VARCHAR(13) NULL AFTER pii;
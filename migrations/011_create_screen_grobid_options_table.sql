@@ -0,0 +1,9 @@
+-- The Grobid options a screen's PDFs are processed with by default, such as citation consolidation, which most
+-- screens do not want to pay for. options holds the fields of parsing.GrobidOptions that the screen sets.
+CREATE TABLE screen_grobid_options (
+    screen_id BIGINT UNSIGNED NOT NULL PRIMARY KEY,
+    options JSON NOT NULL,
+    created_at TIMESTAMP NULL,
+    updated_at TIMESTAMP NULL,
+    CONSTRAINT screen_grobid_options_screen_id_foreign FOREIGN KEY (screen_id) REFERENCES screens (id) ON DELETE CASCADE
+);